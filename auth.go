@@ -0,0 +1,49 @@
+package dicedb
+
+import (
+	"fmt"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+type credentials struct {
+	username string
+	password string
+}
+
+// WithAuth authenticates every connection the client opens, both the command
+// and watch ones and every reconnect, by sending AUTH right after the
+// HANDSHAKE. An empty username sends the password alone. If the server
+// rejects the credentials, NewClient closes the connection and returns the
+// error.
+func WithAuth(username, password string) option {
+	return func(c *Client) {
+		c.auth = &credentials{username: username, password: password}
+	}
+}
+
+func (c *Client) authenticate(clientWire *ClientWire) error {
+	if c.auth == nil {
+		return nil
+	}
+
+	args := []string{c.auth.password}
+	if c.auth.username != "" {
+		args = []string{c.auth.username, c.auth.password}
+	}
+
+	if err := clientWire.Send(&wire.Command{Cmd: "AUTH", Args: args}); err != nil {
+		return fmt.Errorf("could not authenticate: failed to send command: %w", err)
+	}
+
+	resp, err := clientWire.Receive()
+	if err != nil {
+		return fmt.Errorf("could not authenticate: failed to receive response: %w", err)
+	}
+
+	if resp.Status == wire.Status_ERR {
+		return fmt.Errorf("could not authenticate as %q: %s", c.auth.username, resp.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package dicedb
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func authHandler(cmd *wire.Command) *wire.Result {
+	if cmd.Cmd == "AUTH" && !slices.Equal(cmd.Args, []string{"app", "secret"}) {
+		return &wire.Result{Status: wire.Status_ERR, Message: "ERR invalid username-password pair"}
+	}
+	return nil
+}
+
+func TestClient_WithAuth(t *testing.T) {
+	server := newFakeServer(t, authHandler)
+	client, err := NewClient(server.host(), server.port(), WithAuth("app", "secret"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.WatchCh(); err != nil {
+		t.Fatalf("WatchCh() error = %v", err)
+	}
+	server.dropConns()
+	fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+
+	// Every HANDSHAKE, on the command and watch connections and after both
+	// reconnect, is followed by AUTH on the same connection. The watch
+	// connection reconnects on its own goroutine, so wait for it to settle.
+	var auths, hs int
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		auths, hs = 0, 0
+		for _, cmd := range server.commands() {
+			switch cmd.Cmd {
+			case "AUTH":
+				auths++
+			case "HANDSHAKE":
+				hs++
+			}
+		}
+		if (auths == hs && hs >= 4) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if auths != hs || hs < 4 {
+		t.Errorf("got %d AUTH for %d handshakes, want one per handshake and at least 4", auths, hs)
+	}
+
+	if got := client.Config().AuthUsername; got != "app" {
+		t.Errorf("Config().AuthUsername got = %q, want app", got)
+	}
+}
+
+func TestClient_WithAuthRejected(t *testing.T) {
+	server := newFakeServer(t, authHandler)
+	client, err := NewClient(server.host(), server.port(), WithAuth("app", "wrong"))
+	if err == nil {
+		client.Close()
+		t.Fatal("NewClient() with wrong credentials error = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "invalid username-password pair") {
+		t.Errorf("NewClient() error = %v, want the server's reason", err)
+	}
+}
@@ -0,0 +1,172 @@
+package dicedb
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// defaultBackoffMax caps the backoff of a BackoffPolicy without Max.
+const defaultBackoffMax = time.Minute
+
+// ReconnectPolicy decides, after each failed attempt to restore a
+// connection, whether to make another and how long to wait before it. attempt
+// is the number of the attempt that failed, counting consecutive failures from
+// 1, and err is why it failed. Once the policy declines, commands fail with a
+// "max reconnect attempts exceeded" result and the watch connection shuts
+// down, until Reconnect is called explicitly. The command and watch
+// connections share the policy and may consult it at the same time.
+type ReconnectPolicy interface {
+	ShouldRetry(err error, attempt int) (retry bool, delay time.Duration)
+}
+
+// WithReconnectPolicy replaces the default BackoffPolicy with p, and with it
+// the settings of WithReconnectBackoff and WithMaxReconnectAttempts.
+func WithReconnectPolicy(p ReconnectPolicy) option {
+	return func(c *Client) {
+		c.reconnectPolicy = p
+	}
+}
+
+// BackoffPolicy is the default ReconnectPolicy. Without Min it retries
+// straight away. With Min, the wait after n consecutive failures is a random
+// duration between half and all of Min*2^(n-1), capped at Max, so many
+// clients do not retry in lockstep. A zero or negative Max caps it at a minute,
+// or at Min if that is longer. With MaxAttempts, it declines once that many
+// attempts in a row have failed.
+type BackoffPolicy struct {
+	Min, Max    time.Duration
+	MaxAttempts int
+}
+
+func (p BackoffPolicy) ShouldRetry(_ error, attempt int) (bool, time.Duration) {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return false, 0
+	}
+
+	return true, p.delay(attempt)
+}
+
+func (p BackoffPolicy) delay(failures int) time.Duration {
+	if p.Min <= 0 || failures <= 0 {
+		return 0
+	}
+
+	limit := p.Max
+	if limit <= 0 {
+		limit = max(defaultBackoffMax, p.Min)
+	}
+
+	// Doubling stops at limit, so it never overflows.
+	d := min(p.Min, limit)
+	for i := 1; i < failures && d < limit; i++ {
+		if d > limit/2 {
+			d = limit
+			break
+		}
+		d *= 2
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+// WithReconnectBackoff makes consecutive failed reconnects wait before trying
+// again, starting at min and doubling up to max, with jitter so many clients
+// do not retry in lockstep. A zero or negative max caps the wait at a minute.
+// The wait applies to the command and watch connections alike, and starts
+// over once a reconnect succeeds. Without it, reconnects are attempted
+// straight away.
+func WithReconnectBackoff(min, max time.Duration) option {
+	return func(c *Client) {
+		c.backoffPolicy.Min = min
+		c.backoffPolicy.Max = max
+	}
+}
+
+// WithMaxReconnectAttempts stops reconnecting after n consecutive failed
+// reconnects: commands then fail with a "max reconnect attempts exceeded"
+// result and the watch connection shuts down, until Reconnect is called
+// explicitly. Zero, the default, keeps reconnecting without limit.
+func WithMaxReconnectAttempts(n int) option {
+	return func(c *Client) {
+		c.backoffPolicy.MaxAttempts = n
+	}
+}
+
+func (c *Client) policy() ReconnectPolicy {
+	if c.reconnectPolicy != nil {
+		return c.reconnectPolicy
+	}
+	return c.backoffPolicy
+}
+
+func reconnectsExhausted() *ClientError {
+	return clientFailure(ErrMaxReconnect, "could not fire command: "+ErrMaxReconnect.Error(), nil)
+}
+
+// backoff tracks consecutive reconnect failures and what the policy made of
+// the last one.
+type backoff struct {
+	mu       sync.Mutex
+	failures int
+	delay    time.Duration
+	gaveUp   atomic.Bool
+}
+
+// failed counts a failed attempt and asks policy about the next one.
+func (b *backoff) failed(err error, policy ReconnectPolicy) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	retry, delay := policy.ShouldRetry(err, b.failures)
+	b.delay = delay
+	if !retry {
+		b.gaveUp.Store(true)
+	}
+}
+
+// exhausted reports whether the policy has declined to reconnect again.
+func (b *backoff) exhausted() bool {
+	return b.gaveUp.Load()
+}
+
+// wait sleeps for the delay the policy asked for, giving up early with a
+// Timeout error when it would run past deadline or the client is closed
+// meanwhile.
+func (b *backoff) wait(deadline time.Time, done <-chan struct{}) *wire.WireError {
+	b.mu.Lock()
+	d := b.delay
+	b.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+
+	if !deadline.IsZero() && time.Until(deadline) < d {
+		return &wire.WireError{Kind: wire.Timeout, Cause: errors.New("no time left to reconnect")}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-done:
+		return &wire.WireError{Kind: wire.Terminated, Cause: errClosed}
+	}
+}
+
+func (b *backoff) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.delay = 0
+	b.gaveUp.Store(false)
+}
@@ -0,0 +1,191 @@
+package dicedb
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestBackoffPolicy_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		min     time.Duration
+		max     time.Duration
+		want    time.Duration
+	}{
+		{name: "first failure", attempt: 1, min: 100 * time.Millisecond, max: time.Second, want: 100 * time.Millisecond},
+		{name: "doubles", attempt: 3, min: 100 * time.Millisecond, max: time.Second, want: 400 * time.Millisecond},
+		{name: "capped", attempt: 10, min: 100 * time.Millisecond, max: time.Second, want: time.Second},
+		{name: "many failures", attempt: 200, min: 100 * time.Millisecond, max: time.Second, want: time.Second},
+		{name: "past 2^63", attempt: 38, min: time.Second, max: time.Hour, want: time.Hour},
+		{name: "min above max", attempt: 1, min: time.Second, max: 100 * time.Millisecond, want: 100 * time.Millisecond},
+		{name: "no max", attempt: 5, min: 100 * time.Millisecond, want: 1600 * time.Millisecond},
+		{name: "negative max", attempt: 3, min: 100 * time.Millisecond, max: -time.Second, want: 400 * time.Millisecond},
+		{name: "no max, many failures", attempt: 200, min: time.Second, want: time.Minute},
+		{name: "no max, past 2^63", attempt: 35, min: time.Second, want: time.Minute},
+		{name: "no max, min above the default", attempt: 10, min: 2 * time.Minute, want: 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := BackoffPolicy{Min: tt.min, Max: tt.max}
+			for i := 0; i < 20; i++ {
+				retry, got := p.ShouldRetry(nil, tt.attempt)
+				if !retry || got < tt.want/2 || got > tt.want {
+					t.Errorf("ShouldRetry() got = %v %v, want true and between %v and %v", retry, got, tt.want/2, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffPolicy_NoDelay(t *testing.T) {
+	if _, got := (BackoffPolicy{}).ShouldRetry(nil, 1); got != 0 {
+		t.Errorf("ShouldRetry() without backoff got = %v, want 0", got)
+	}
+
+	b := &backoff{}
+	if err := b.wait(time.Time{}, nil); err != nil {
+		t.Errorf("wait() before any failure got = %v, want nil", err)
+	}
+}
+
+func TestBackoffPolicy_MaxAttempts(t *testing.T) {
+	p := BackoffPolicy{MaxAttempts: 2}
+	for attempt, want := range map[int]bool{1: true, 2: false} {
+		if got, _ := p.ShouldRetry(nil, attempt); got != want {
+			t.Errorf("ShouldRetry(attempt %d) got = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestBackoff_WaitPastDeadline(t *testing.T) {
+	b := &backoff{}
+	b.failed(errors.New("refused"), BackoffPolicy{Min: time.Minute, Max: time.Hour})
+
+	err := b.wait(time.Now().Add(time.Second), nil)
+	if err == nil || err.Kind != wire.Timeout {
+		t.Errorf("wait() got = %v, want a Timeout error", err)
+	}
+}
+
+func TestClient_ReconnectBackoff(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithReconnectBackoff(50*time.Millisecond, time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	client.backoff.failed(errors.New("refused"), client.policy())
+	client.backoff.failed(errors.New("refused"), client.policy())
+
+	start := time.Now()
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Reconnect() took %v, want at least 50ms of backoff", elapsed)
+	}
+	if got := client.backoff.failures; got != 0 {
+		t.Errorf("failures after successful reconnect got = %d, want 0", got)
+	}
+}
+
+func TestClient_MaxReconnectAttempts(t *testing.T) {
+	var refuse atomic.Bool
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "HANDSHAKE" && refuse.Load() {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR refused"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithMaxReconnectAttempts(2))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	refuse.Store(true)
+	server.dropConns()
+
+	var resp *wire.Result
+	for i := 0; i < 5; i++ {
+		resp = client.Fire(&wire.Command{Cmd: "PING"})
+	}
+	if resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, ErrMaxReconnect.Error()) {
+		t.Errorf("Fire() got = %v, want a max reconnect attempts error", resp)
+	}
+	if got := len(handshakes(server)); got != 3 {
+		t.Errorf("got %d handshakes, want 3: the first plus 2 reconnects", got)
+	}
+
+	refuse.Store(false)
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() after the limit error = %v", err)
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() after Reconnect() status = %s: %s", resp.Status, resp.Message)
+	}
+}
+
+// recordingPolicy declines as soon as attempt reaches giveUpAt.
+type recordingPolicy struct {
+	mu       sync.Mutex
+	giveUpAt int
+	attempts []int
+	errs     []error
+}
+
+func (p *recordingPolicy) ShouldRetry(err error, attempt int) (bool, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.attempts = append(p.attempts, attempt)
+	p.errs = append(p.errs, err)
+	return attempt < p.giveUpAt, 0
+}
+
+func TestClient_WithReconnectPolicy(t *testing.T) {
+	var refuse atomic.Bool
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "HANDSHAKE" && refuse.Load() {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR refused"}
+		}
+		return nil
+	})
+	policy := &recordingPolicy{giveUpAt: 2}
+	client, err := NewClient(server.host(), server.port(), WithReconnectPolicy(policy), WithMaxReconnectAttempts(10))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	refuse.Store(true)
+	server.dropConns()
+
+	var resp *wire.Result
+	for i := 0; i < 5; i++ {
+		resp = client.Fire(&wire.Command{Cmd: "PING"})
+	}
+	if resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, ErrMaxReconnect.Error()) {
+		t.Errorf("Fire() got = %v, want the policy to have given up", resp)
+	}
+
+	policy.mu.Lock()
+	defer policy.mu.Unlock()
+	if !slices.Equal(policy.attempts, []int{1, 2}) {
+		t.Errorf("ShouldRetry() attempts got = %v, want [1 2]", policy.attempts)
+	}
+	for i, err := range policy.errs {
+		if err == nil {
+			t.Errorf("ShouldRetry() error for attempt %d got = nil, want the handshake failure", i+1)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package dicedb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// BatchError reports the commands of a batch that did not succeed. Succeeded
+// counts the commands that did.
+type BatchError struct {
+	Succeeded int
+	Failures  []BatchFailure
+}
+
+// BatchFailure is a failed command, identified by its index in the batch.
+type BatchFailure struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	first := e.Failures[0]
+	return fmt.Sprintf("%d of %d commands failed, first at index %d: %s", len(e.Failures), e.Succeeded+len(e.Failures), first.Index, first.Err)
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// batchErr collects the failed results of a batch, returning nil when every
+// command succeeded. failures holds the *ClientError each result the client
+// made came from, at the same index.
+func batchErr(results []*wire.Result, failures []*ClientError) error {
+	batchErr := &BatchError{}
+	for i, resp := range results {
+		if err := outcomeErr(resp, failures[i]); err != nil {
+			batchErr.Failures = append(batchErr.Failures, BatchFailure{Index: i, Err: err})
+			continue
+		}
+		batchErr.Succeeded++
+	}
+
+	if len(batchErr.Failures) == 0 {
+		return nil
+	}
+	return batchErr
+}
+
+// FireBatch pipelines cmds, writing every command before reading any reply,
+// and returns their results in the same order. A lost connection is only
+// restored for the first write: once part of the batch is on the wire,
+// resending it could apply commands twice, so the rest of the batch fails
+// instead. WithCommandTimeout bounds each write and read.
+func (c *Client) FireBatch(cmds []*wire.Command) []*wire.Result {
+	results, _ := c.fireBatch(cmds)
+	return results
+}
+
+// FireBatchContext is FireBatch bounded by ctx. Its deadline applies to the
+// whole exchange. Once ctx is done no further commands are written, a write or
+// read in progress is interrupted, and the results of the commands written so
+// far are returned together with ctx's error; those left unanswered fail, and
+// the connection, now out of step with the server, is replaced on the next
+// command.
+func (c *Client) FireBatchContext(ctx context.Context, cmds []*wire.Command) ([]*wire.Result, error) {
+	results, _, err := c.fireBatchContext(ctx, cmds)
+	return results, err
+}
+
+// Pipeline queues commands to be sent together by Exec.
+type Pipeline struct {
+	client *Client
+	cmds   []*wire.Command
+}
+
+// Pipeline returns an empty pipeline on the client.
+func (c *Client) Pipeline() *Pipeline {
+	return &Pipeline{client: c}
+}
+
+// Add queues cmd and returns the pipeline, so calls can be chained.
+func (p *Pipeline) Add(cmd *wire.Command) *Pipeline {
+	p.cmds = append(p.cmds, cmd)
+	return p
+}
+
+// Len returns the number of queued commands.
+func (p *Pipeline) Len() int {
+	return len(p.cmds)
+}
+
+// Exec sends the queued commands as one FireBatch, which holds the connection
+// until every reply is read, and empties the pipeline. The results come back in
+// the order the commands were added. When any of them failed, whether the
+// server rejected it or it could not be written, the error is a *BatchError
+// listing them by index.
+func (p *Pipeline) Exec() ([]*wire.Result, error) {
+	return p.ExecContext(context.Background())
+}
+
+// ExecContext is Exec bounded by ctx, as FireBatchContext is. Once ctx is
+// done, the results of the commands written so far are returned with ctx's
+// error.
+func (p *Pipeline) ExecContext(ctx context.Context) ([]*wire.Result, error) {
+	cmds := p.cmds
+	p.cmds = nil
+
+	results, failures, err := p.client.fireBatchContext(ctx, cmds)
+	if err != nil {
+		return results, err
+	}
+	return results, batchErr(results, failures)
+}
+
+// fireBatch is fireBatchContext without a ctx to bound it.
+func (c *Client) fireBatch(cmds []*wire.Command) ([]*wire.Result, []*ClientError) {
+	results, failures, _ := c.fireBatchContext(context.Background(), cmds)
+	return results, failures
+}
+
+// fireBatchContext returns the results of cmds together with the *ClientError
+// each one the client made came from, at the same index.
+func (c *Client) fireBatchContext(ctx context.Context, cmds []*wire.Command) ([]*wire.Result, []*ClientError, error) {
+	if err := c.waitResumed(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, err
+		}
+		results, failures := failBatch(len(cmds), aborted(err))
+		return results, failures, nil
+	}
+
+	ctx, leave, ok := c.enter(ctx)
+	if !ok {
+		results, failures := failBatch(len(cmds), shuttingDown())
+		return results, failures, nil
+	}
+	defer leave()
+
+	results, failures, err := c.sendBatch(ctx, cmds)
+	for _, resp := range results {
+		c.health.track(resp)
+	}
+
+	return results, failures, err
+}
+
+// failBatch returns n results all made from failure.
+func failBatch(n int, failure *ClientError) ([]*wire.Result, []*ClientError) {
+	results := make([]*wire.Result, n)
+	failures := make([]*ClientError, n)
+	for i := range results {
+		results[i], failures[i] = failure.result(), failure
+	}
+	return results, failures
+}
+
+// sendBatch holds mainMu for the whole exchange so replies line up with their
+// commands.
+func (c *Client) sendBatch(ctx context.Context, cmds []*wire.Command) ([]*wire.Result, []*ClientError, error) {
+	results := make([]*wire.Result, len(cmds))
+	failures := make([]*ClientError, len(cmds))
+	if len(cmds) == 0 {
+		return results, failures, ctx.Err()
+	}
+
+	if c.recorder != nil {
+		for i, cmd := range cmds {
+			if err := ctx.Err(); err != nil {
+				return results[:i], failures[:i], err
+			}
+			results[i] = c.recorder.record(cmd)
+		}
+		return results, failures, nil
+	}
+
+	c.mainMu.Lock()
+	defer c.unlockMain()
+
+	var failure *ClientError
+	switch {
+	case c.State() == StateClosed:
+		failure = clientClosed()
+	case c.mainWire == nil:
+		failure = notConnected()
+	case c.backoff.exhausted():
+		failure = reconnectsExhausted()
+	}
+	if failure != nil {
+		results, failures := failBatch(len(cmds), failure)
+		return results, failures, nil
+	}
+
+	deadline, _ := ctx.Deadline()
+	if !deadline.IsZero() || c.commandTimeout > 0 {
+		_ = c.mainWire.SetDeadline(deadline)
+		defer c.clearDeadline()
+	}
+
+	interrupt := c.interruptOn(ctx)
+	defer interrupt.release()
+
+	sent := 0
+	var ctxErr error
+	for i, cmd := range cmds {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+
+		var err *wire.WireError
+		if i == 0 {
+			err = ExecuteVoid(c.mainRetrier, []wire.ErrKind{wire.Terminated}, func() *wire.WireError {
+				interrupt.arm(deadline, c.commandTimeout)
+				return c.mainWire.Send(cmd)
+			}, func() *wire.WireError {
+				if err := ctx.Err(); err != nil {
+					return &wire.WireError{Kind: wire.Timeout, Cause: err}
+				}
+				if err := c.restoreMainWireBy(deadline); err != nil {
+					return err
+				}
+				interrupt.follow(c.mainWire)
+				return nil
+			})
+		} else {
+			interrupt.arm(deadline, c.commandTimeout)
+			err = c.mainWire.Send(cmd)
+		}
+
+		if err != nil {
+			ctxErr = ctx.Err()
+			failure := c.commandFailure(ctx, time.Time{}, err, sendFailure)
+			for j := i; j < len(cmds); j++ {
+				results[j], failures[j] = failure.result(), failure
+			}
+			break
+		}
+		sent++
+	}
+
+	for i := 0; i < sent; i++ {
+		interrupt.arm(deadline, c.commandTimeout)
+		resp, err := c.mainWire.Receive()
+		if err != nil {
+			if ctxErr == nil {
+				ctxErr = ctx.Err()
+			}
+			failure := c.commandFailure(ctx, time.Time{}, err, receiveFailure)
+			for j := i; j < sent; j++ {
+				results[j], failures[j] = failure.result(), failure
+			}
+			break
+		}
+		results[i] = resp
+	}
+
+	if ctxErr != nil {
+		return results[:sent], failures[:sent], ctxErr
+	}
+
+	return results, failures, nil
+}
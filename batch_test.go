@@ -0,0 +1,178 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_FireBatchContext(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "ECHO" {
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_ECHORes{ECHORes: &wire.ECHORes{Message: cmd.Args[0]}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	cmds := []*wire.Command{
+		{Cmd: "ECHO", Args: []string{"a"}},
+		{Cmd: "ECHO", Args: []string{"b"}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := client.FireBatchContext(ctx, cmds)
+	if !errors.Is(err, context.Canceled) || len(results) != 0 {
+		t.Errorf("FireBatchContext() got = %d results, %v, want 0, %v", len(results), err, context.Canceled)
+	}
+
+	// Nothing was left half-read on the connection.
+	results, err = client.FireBatchContext(context.Background(), cmds)
+	if err != nil || len(results) != 2 {
+		t.Fatalf("FireBatchContext() got = %d results, %v, want 2, nil", len(results), err)
+	}
+	for i, want := range []string{"a", "b"} {
+		if got := results[i].GetECHORes().GetMessage(); got != want {
+			t.Errorf("FireBatchContext() result %d got = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestClient_FireBatchInterrupted(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []option
+		ctx  func() (context.Context, context.CancelFunc)
+		want error
+	}{
+		{
+			name: "deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			want: context.DeadlineExceeded,
+		},
+		{
+			name: "cancel",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			want: context.Canceled,
+		},
+		{
+			name: "command timeout",
+			opts: []option{WithCommandTimeout(50 * time.Millisecond)},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			want: ErrTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+			server := slowServer(t, release)
+			client, err := NewClient(server.host(), server.port(), tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			start := time.Now()
+			results, failures, err := client.fireBatchContext(ctx, []*wire.Command{{Cmd: "PING"}, {Cmd: "SLOW"}})
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Fatalf("FireBatchContext() took %s, want it interrupted", elapsed)
+			}
+			if err == nil {
+				err = batchErr(results, failures)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("FireBatchContext() error = %v, want %v", err, tt.want)
+			}
+
+			// The interrupted connection is replaced for the next command.
+			fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+		})
+	}
+}
+
+func TestPipeline_Exec(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		switch cmd.Cmd {
+		case "ECHO":
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_ECHORes{ECHORes: &wire.ECHORes{Message: cmd.Args[0]}}}
+		case "BAD":
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR unknown command"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	p := client.Pipeline().
+		Add(&wire.Command{Cmd: "ECHO", Args: []string{"a"}}).
+		Add(&wire.Command{Cmd: "ECHO", Args: []string{"b"}})
+	results, err := p.Exec()
+	if err != nil || len(results) != 2 {
+		t.Fatalf("Exec() got = %d results, %v, want 2, nil", len(results), err)
+	}
+	for i, want := range []string{"a", "b"} {
+		if got := results[i].GetECHORes().GetMessage(); got != want {
+			t.Errorf("Exec() result %d got = %q, want %q", i, got, want)
+		}
+	}
+	if p.Len() != 0 {
+		t.Errorf("Len() after Exec() got = %d, want 0", p.Len())
+	}
+
+	results, err = p.Add(&wire.Command{Cmd: "ECHO", Args: []string{"c"}}).Add(&wire.Command{Cmd: "BAD"}).Exec()
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("Exec() error = %v, want a BatchError for index 1", err)
+	}
+	if len(results) != 2 || results[0].GetECHORes().GetMessage() != "c" {
+		t.Errorf("Exec() results got = %v, want c first", results)
+	}
+}
+
+func TestPipeline_ExecContext(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := client.Pipeline().Add(&wire.Command{Cmd: "PING"})
+	results, err := p.ExecContext(ctx)
+	if !errors.Is(err, context.Canceled) || len(results) != 0 {
+		t.Errorf("ExecContext() got = %d results, %v, want 0, %v", len(results), err, context.Canceled)
+	}
+	if p.Len() != 0 {
+		t.Errorf("Len() after ExecContext() got = %d, want 0", p.Len())
+	}
+
+	results, err = p.Add(&wire.Command{Cmd: "PING"}).ExecContext(context.Background())
+	if err != nil || len(results) != 1 {
+		t.Errorf("ExecContext() got = %d results, %v, want 1, nil", len(results), err)
+	}
+}
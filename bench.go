@@ -0,0 +1,228 @@
+package dicedb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// BenchResult summarizes a Benchmark run. Latencies are measured per Fire
+// call, including the time spent waiting for the connection. The percentiles
+// are read from a histogram and are within about 6% of the measured value;
+// Max is exact.
+type BenchResult struct {
+	Commands   int64
+	Errors     int64
+	Elapsed    time.Duration
+	Throughput float64 // commands per second
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+}
+
+// Benchmark fires cmd repeatedly from concurrency goroutines for duration and
+// reports throughput and latency percentiles. Replies with Status_ERR count as
+// errors but are still timed; commands cut off when the run ends are left out.
+func (c *Client) Benchmark(cmd *wire.Command, concurrency int, duration time.Duration) (BenchResult, error) {
+	return c.BenchmarkContext(context.Background(), cmd, concurrency, duration)
+}
+
+// BenchmarkContext is Benchmark ended early when ctx is done or the client is
+// closed, in which case it returns the result gathered until then together
+// with the reason.
+func (c *Client) BenchmarkContext(ctx context.Context, cmd *wire.Command, concurrency int, duration time.Duration) (BenchResult, error) {
+	if concurrency <= 0 {
+		return BenchResult{}, fmt.Errorf("concurrency must be positive, got %d", concurrency)
+	}
+	if duration <= 0 {
+		return BenchResult{}, fmt.Errorf("duration must be positive, got %s", duration)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(parent, duration)
+	defer cancel()
+	// The connection's deadline can pass a moment before ctx reports it, so
+	// the clock is checked too.
+	end, _ := ctx.Deadline()
+	over := func() bool {
+		return ctx.Err() != nil || !time.Now().Before(end)
+	}
+
+	latencies := make([]latencyHistogram, concurrency)
+	errs := make([]int64, concurrency)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for !over() && c.State() != StateClosed {
+				began := time.Now()
+				resp := c.FireContext(ctx, cmd)
+				if over() {
+					break
+				}
+				latencies[i].record(time.Since(began))
+				if resp.Status == wire.Status_ERR {
+					errs[i]++
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var all latencyHistogram
+	for i := range latencies {
+		all.merge(&latencies[i])
+	}
+
+	res := BenchResult{Elapsed: time.Since(start)}
+	for _, n := range errs {
+		res.Errors += n
+	}
+	res.Commands = all.n
+	if all.n > 0 {
+		res.Throughput = float64(all.n) / res.Elapsed.Seconds()
+		res.P50 = all.percentile(0.50)
+		res.P90 = all.percentile(0.90)
+		res.P99 = all.percentile(0.99)
+		res.Max = all.max
+	}
+
+	switch {
+	case c.State() == StateClosed:
+		return res, errClosed
+	case parent.Err() != nil:
+		return res, parent.Err()
+	default:
+		return res, nil
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted, which must not
+// be empty.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+// histSub is the number of equal buckets latencyHistogram splits each power of
+// two into.
+const (
+	histSub     = 16
+	histSubBits = 4 // log2(histSub)
+)
+
+// latencyHistogram counts durations in buckets that double in width every
+// histSub buckets, so it takes the same memory however many it records and
+// reads percentiles back to within 1/histSub.
+type latencyHistogram struct {
+	counts [64 * histSub]int64
+	n      int64
+	max    time.Duration
+}
+
+func (h *latencyHistogram) record(d time.Duration) {
+	h.counts[histBucket(d)]++
+	h.n++
+	h.max = max(h.max, d)
+}
+
+func (h *latencyHistogram) merge(o *latencyHistogram) {
+	for i, n := range o.counts {
+		h.counts[i] += n
+	}
+	h.n += o.n
+	h.max = max(h.max, o.max)
+}
+
+// percentile returns the nearest-rank percentile p as the upper edge of the
+// bucket it falls in, capped at the largest duration recorded. h must not be
+// empty.
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	rank := max(1, int64(math.Ceil(p*float64(h.n))))
+	var seen int64
+	for i, n := range h.counts {
+		seen += n
+		if seen >= rank {
+			if edge := histLowest(i+1) - 1; edge > 0 && edge < h.max {
+				return edge
+			}
+			return h.max
+		}
+	}
+
+	return h.max
+}
+
+// histBucket returns the bucket d is counted in. Durations below histSub
+// nanoseconds get one bucket each; above that, each power of two is split
+// into histSub equal buckets.
+func histBucket(d time.Duration) int {
+	v := uint64(max(d, 0))
+	if v < histSub {
+		return int(v)
+	}
+	e := bits.Len64(v) - 1
+	shift := e - histSubBits
+	return (shift+1)*histSub + int(v>>shift) - histSub
+}
+
+// histLowest returns the smallest duration counted in bucket i.
+func histLowest(i int) time.Duration {
+	if i < histSub {
+		return time.Duration(i)
+	}
+	shift := i/histSub - 1
+	return time.Duration(uint64(histSub+i%histSub) << shift)
+}
+
+// LatencyStats summarizes the round trips measured by Latency.
+type LatencyStats struct {
+	Samples int
+	Min     time.Duration
+	Avg     time.Duration
+	P50     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+}
+
+// Latency fires PING samples times, one after the other, and reports the round
+// trip times. Each PING takes its turn on the connection like any other
+// command, so concurrent commands are neither blocked for the whole probe nor
+// counted in it. It stops at the first failed PING.
+func (c *Client) Latency(samples int) (LatencyStats, error) {
+	if samples <= 0 {
+		return LatencyStats{}, fmt.Errorf("samples must be positive, got %d", samples)
+	}
+
+	rtts := make([]time.Duration, samples)
+	var total time.Duration
+	for i := range rtts {
+		start := time.Now()
+		_, err := c.do(&wire.Command{Cmd: "PING"})
+		rtts[i] = time.Since(start)
+		if err != nil {
+			return LatencyStats{}, fmt.Errorf("ping %d of %d failed: %w", i+1, samples, err)
+		}
+		total += rtts[i]
+	}
+
+	slices.Sort(rtts)
+	return LatencyStats{
+		Samples: samples,
+		Min:     rtts[0],
+		Avg:     total / time.Duration(samples),
+		P50:     percentile(rtts, 0.50),
+		P99:     percentile(rtts, 0.99),
+		Max:     rtts[samples-1],
+	}, nil
+}
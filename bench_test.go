@@ -0,0 +1,138 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{p: 0.50, want: 50 * time.Millisecond},
+		{p: 0.90, want: 90 * time.Millisecond},
+		{p: 0.99, want: 99 * time.Millisecond},
+		{p: 1, want: 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) got = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	var h latencyHistogram
+	for i := 1; i <= 1000; i++ {
+		h.record(time.Duration(i) * time.Microsecond)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{p: 0.50, want: 500 * time.Microsecond},
+		{p: 0.90, want: 900 * time.Microsecond},
+		{p: 0.99, want: 990 * time.Microsecond},
+		{p: 1, want: 1000 * time.Microsecond},
+	}
+
+	for _, tt := range tests {
+		got := h.percentile(tt.p)
+		if got < tt.want || float64(got-tt.want) > float64(tt.want)/histSub {
+			t.Errorf("percentile(%v) got = %v, want within 1/%d above %v", tt.p, got, histSub, tt.want)
+		}
+	}
+	if h.n != 1000 || h.max != time.Millisecond {
+		t.Errorf("histogram got n = %d, max = %v, want 1000 and %v", h.n, h.max, time.Millisecond)
+	}
+
+	for _, d := range []time.Duration{0, 15, 16, 17, 1000, time.Second, time.Hour, math.MaxInt64} {
+		i := histBucket(d)
+		if next := histLowest(i + 1); d < histLowest(i) || (next > 0 && d >= next) {
+			t.Errorf("histBucket(%d) = %d, which covers [%d, %d)", d, i, histLowest(i), histLowest(i+1))
+		}
+	}
+}
+
+func TestClient_Benchmark(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	res, err := client.Benchmark(&wire.Command{Cmd: "PING"}, 4, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Benchmark() error = %v", err)
+	}
+	if res.Commands == 0 || res.Errors != 0 || res.Throughput <= 0 {
+		t.Errorf("Benchmark() got = %+v, want commands, no errors and a throughput", res)
+	}
+	if res.P50 > res.P90 || res.P90 > res.P99 || res.P99 > res.Max {
+		t.Errorf("Benchmark() percentiles got = %v, %v, %v, %v, want them ordered", res.P50, res.P90, res.P99, res.Max)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.BenchmarkContext(ctx, &wire.Command{Cmd: "PING"}, 1, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("BenchmarkContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_BenchmarkCutOff(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := slowServer(t, release)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Every SLOW outlasts the run, so none of them is counted.
+	res, err := client.Benchmark(&wire.Command{Cmd: "SLOW"}, 2, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Benchmark() error = %v", err)
+	}
+	if res.Commands != 0 || res.Errors != 0 {
+		t.Errorf("Benchmark() got = %+v, want no commands and no errors", res)
+	}
+}
+
+func TestClient_Latency(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	stats, err := client.Latency(20)
+	if err != nil {
+		t.Fatalf("Latency() error = %v", err)
+	}
+	if stats.Samples != 20 || stats.Min <= 0 || stats.Min > stats.P50 || stats.P50 > stats.P99 || stats.P99 > stats.Max {
+		t.Errorf("Latency() got = %+v, want 20 ordered samples", stats)
+	}
+	if stats.Avg < stats.Min || stats.Avg > stats.Max {
+		t.Errorf("Latency() avg got = %v, want it between %v and %v", stats.Avg, stats.Min, stats.Max)
+	}
+
+	if _, err := client.Latency(0); err == nil {
+		t.Errorf("Latency(0) error = nil, want an error")
+	}
+}
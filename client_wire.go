@@ -4,8 +4,12 @@
 package dicedb
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dicedb/dicedb-go/internal"
@@ -14,21 +18,92 @@ import (
 
 type ClientWire struct {
 	*internal.ProtobufTCPWire
+	conn net.Conn
 }
 
+const defaultDialTimeout = 5 * time.Second
+
 func NewClientWire(maxMsgSize int, host string, port int) (*ClientWire, *wire.WireError) {
-	addr := fmt.Sprintf("%s:%d", host, port)
-	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	return dialClientWire(maxMsgSize, host, port, dialOptions{timeout: defaultDialTimeout})
+}
+
+// dialOptions controls how dialClientWire connects.
+type dialOptions struct {
+	timeout   time.Duration // bounds the dial and TLS handshake together
+	tlsConfig *tls.Config   // ServerName defaults to the dialed host
+	dialer    func(ctx context.Context, addr string) (net.Conn, error)
+	keepAlive time.Duration // zero leaves the dialer's keepalive alone
+}
+
+// dialClientWire connects to host:port through opts.dialer, or a plain TCP
+// dial when it is nil, and then over TLS when opts.tlsConfig is set.
+func dialClientWire(maxMsgSize int, host string, port int, opts dialOptions) (*ClientWire, *wire.WireError) {
+	addr := hostPort(host, port)
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	dialer := opts.dialer
+	if dialer == nil {
+		d := &net.Dialer{}
+		dialer = func(ctx context.Context, addr string) (net.Conn, error) {
+			return d.DialContext(ctx, "tcp", addr)
+		}
+	}
+
+	conn, err := dialer(ctx, addr)
 	if err != nil {
 		return nil, &wire.WireError{Kind: wire.NotEstablished, Cause: err}
 	}
+
+	// Keepalive is set on the TCP connection itself, below any TLS layer.
+	if tcpConn, ok := conn.(*net.TCPConn); ok && opts.keepAlive > 0 {
+		if err := setKeepAlive(tcpConn, opts.keepAlive); err != nil {
+			_ = conn.Close()
+			return nil, &wire.WireError{Kind: wire.NotEstablished, Cause: err}
+		}
+	}
+
+	if opts.tlsConfig != nil {
+		cfg := opts.tlsConfig.Clone()
+		if cfg.ServerName == "" {
+			cfg.ServerName = host
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, &wire.WireError{Kind: wire.NotEstablished, Cause: err}
+		}
+		conn = tlsConn
+	}
+
 	w := &ClientWire{
 		ProtobufTCPWire: internal.NewProtobufTCPWire(maxMsgSize, conn),
+		conn:            conn,
 	}
 
 	return w, nil
 }
 
+// hostPort joins host and port into a dial address, bracketing IPv6 literals
+// such as ::1. A host already in brackets is taken as is.
+func hostPort(host string, port int) string {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func setKeepAlive(conn *net.TCPConn, period time.Duration) error {
+	if err := conn.SetKeepAlive(true); err != nil {
+		return fmt.Errorf("could not enable keepalive: %w", err)
+	}
+	if err := conn.SetKeepAlivePeriod(period); err != nil {
+		return fmt.Errorf("could not set keepalive period: %w", err)
+	}
+	return nil
+}
+
 func (cw *ClientWire) Send(cmd *wire.Command) *wire.WireError {
 	return cw.ProtobufTCPWire.Send(cmd)
 }
@@ -40,6 +115,6 @@ func (cw *ClientWire) Receive() (*wire.Result, *wire.WireError) {
 	return resp, err
 }
 
-func (cw *ClientWire) Close() {
-	cw.ProtobufTCPWire.Close()
+func (cw *ClientWire) Close() error {
+	return cw.ProtobufTCPWire.Close()
 }
@@ -0,0 +1,147 @@
+package dicedb
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_TLS(t *testing.T) {
+	server, tlsConfig := newTLSFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET.WATCH" {
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Fingerprint64: 42}
+		}
+		return nil
+	})
+
+	// The certificate is issued for localhost, so connecting to 127.0.0.1
+	// only verifies with an explicit ServerName.
+	tlsConfig.ServerName = "localhost"
+	client, err := NewClient(server.host(), server.port(), WithTLSConfig(tlsConfig))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() got = %v %q, want %v", resp.Status, resp.Message, wire.Status_OK)
+	}
+
+	// The watch connection must be encrypted too; a plaintext handshake would
+	// never complete against the TLS listener.
+	if _, err := client.OnWatch("k", func(*wire.Result) {}); err != nil {
+		t.Errorf("OnWatch() error = %v", err)
+	}
+}
+
+func TestClient_TLSServerNameDefaultsToHost(t *testing.T) {
+	server, tlsConfig := newTLSFakeServer(t, nil)
+
+	client, err := NewClient("localhost", server.port(), WithTLSConfig(tlsConfig))
+	if err != nil {
+		t.Fatalf("NewClient() with host localhost error = %v, want nil", err)
+	}
+	client.Close()
+
+	tlsConfig.ServerName = ""
+	if _, err := NewClient(server.host(), server.port(), WithTLSConfig(tlsConfig)); err == nil {
+		t.Errorf("NewClient() with host %s error = nil, want a certificate error", server.host())
+	}
+}
+
+func TestClient_Dialer(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET.WATCH" {
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Fingerprint64: 42}
+		}
+		return nil
+	})
+
+	var mu sync.Mutex
+	var addrs []string
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		mu.Lock()
+		addrs = append(addrs, addr)
+		mu.Unlock()
+
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", net.JoinHostPort(server.host(), strconv.Itoa(server.port())))
+	}
+
+	// The host does not resolve; only the custom dialer can reach the server.
+	client, err := NewClient("dicedb.invalid", 7379, WithDialer(dialer))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.OnWatch("k", func(*wire.Result) {}); err != nil {
+		t.Fatalf("OnWatch() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(addrs, []string{"dicedb.invalid:7379", "dicedb.invalid:7379"}) {
+		t.Errorf("dialer got addrs = %v, want the command and watch connections", addrs)
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "localhost", want: "localhost:7379"},
+		{host: "10.0.0.1", want: "10.0.0.1:7379"},
+		{host: "::1", want: "[::1]:7379"},
+		{host: "[::1]", want: "[::1]:7379"},
+		{host: "fe80::1%eth0", want: "[fe80::1%eth0]:7379"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := hostPort(tt.host, 7379); got != tt.want {
+				t.Errorf("hostPort() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClient_IPv6 checks the address handed to the dialer for IPv6 hosts; the
+// dialer itself connects to the IPv4 fake server, so no IPv6 stack is needed.
+func TestClient_IPv6(t *testing.T) {
+	for _, host := range []string{"::1", "[::1]"} {
+		t.Run(host, func(t *testing.T) {
+			server := newFakeServer(t, nil)
+			var mu sync.Mutex
+			var addrs []string
+			dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+				mu.Lock()
+				addrs = append(addrs, addr)
+				mu.Unlock()
+
+				if _, _, err := net.SplitHostPort(addr); err != nil {
+					return nil, err
+				}
+				var d net.Dialer
+				return d.DialContext(ctx, "tcp", net.JoinHostPort(server.host(), strconv.Itoa(server.port())))
+			}
+
+			client, err := NewClient(host, 7379, WithDialer(dialer))
+			if err != nil {
+				t.Fatalf("NewClient(%s) error = %v", host, err)
+			}
+			defer client.Close()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(addrs, []string{"[::1]:7379"}) {
+				t.Errorf("dialer got addrs = %v, want [[::1]:7379]", addrs)
+			}
+		})
+	}
+}
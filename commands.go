@@ -0,0 +1,52 @@
+package dicedb
+
+import (
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// Get returns the value of key, or ErrKeyNotFound when it does not exist.
+func (c *Client) Get(key string) (string, error) {
+	resp, err := c.do(&wire.Command{Cmd: "GET", Args: []string{c.key(key)}})
+	if err != nil {
+		return "", err
+	}
+
+	// As with GETEX, the payload is left out when the key does not exist.
+	if resp.GetGETRes() == nil {
+		return "", ErrKeyNotFound
+	}
+
+	return resp.GetGETRes().GetValue(), nil
+}
+
+// Set sets key to value, with no expiry.
+func (c *Client) Set(key, value string) error {
+	_, err := c.do(&wire.Command{Cmd: "SET", Args: []string{c.key(key), value}})
+	return err
+}
+
+// Del deletes keys and returns how many of them existed.
+func (c *Client) Del(keys ...string) (int64, error) {
+	args := make([]string, len(keys))
+	for i, key := range keys {
+		args[i] = c.key(key)
+	}
+
+	resp, err := c.do(&wire.Command{Cmd: "DEL", Args: args})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.GetDELRes().GetCount(), nil
+}
+
+// Incr increments the integer at key by one and returns the new value. A
+// missing key counts as zero.
+func (c *Client) Incr(key string) (int64, error) {
+	resp, err := c.do(&wire.Command{Cmd: "INCR", Args: []string{c.key(key)}})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.GetINCRRes().GetValue(), nil
+}
@@ -0,0 +1,71 @@
+package dicedb
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_Commands(t *testing.T) {
+	values := map[string]string{}
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		switch cmd.Cmd {
+		case "SET":
+			values[cmd.Args[0]] = cmd.Args[1]
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_SETRes{SETRes: &wire.SETRes{}}}
+		case "GET":
+			v, ok := values[cmd.Args[0]]
+			if !ok {
+				return &wire.Result{Status: wire.Status_OK, Message: "OK"}
+			}
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_GETRes{GETRes: &wire.GETRes{Value: v}}}
+		case "DEL":
+			var n int64
+			for _, k := range cmd.Args {
+				if _, ok := values[k]; ok {
+					delete(values, k)
+					n++
+				}
+			}
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_DELRes{DELRes: &wire.DELRes{Count: n}}}
+		case "INCR":
+			if cmd.Args[0] == "app:text" {
+				return &wire.Result{Status: wire.Status_ERR, Message: "ERR value is not an integer or out of range"}
+			}
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: 1}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithKeyPrefix("app:"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Set("k1", "v1"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, err := client.Get("k1"); err != nil || got != "v1" {
+		t.Errorf("Get() got = %q, %v, want v1", got, err)
+	}
+	if _, err := client.Get("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get() of a missing key error = %v, want ErrKeyNotFound", err)
+	}
+
+	if n, err := client.Del("k1", "missing"); err != nil || n != 1 {
+		t.Errorf("Del() got = %d, %v, want 1", n, err)
+	}
+	cmds := server.commands()
+	if last := cmds[len(cmds)-1]; !slices.Equal(last.Args, []string{"app:k1", "app:missing"}) {
+		t.Errorf("Del() sent %v, want prefixed keys", last.Args)
+	}
+
+	if n, err := client.Incr("counter"); err != nil || n != 1 {
+		t.Errorf("Incr() got = %d, %v, want 1", n, err)
+	}
+	if _, err := client.Incr("text"); err == nil {
+		t.Error("Incr() of a non-integer error = nil, want the server error")
+	}
+}
@@ -0,0 +1,109 @@
+package dicedb
+
+import "time"
+
+const redacted = "<redacted>"
+
+// ClientConfig is a snapshot of the settings a client runs with, as returned
+// by Config. Handshake args are redacted since they may carry credentials, and
+// of the WithAuth credentials only the username is kept. Host and Port name the
+// server in use, and Addrs lists every server of a client made with
+// NewClientMulti.
+type ClientConfig struct {
+	ID                    string
+	Name                  string
+	Host                  string
+	Port                  int
+	Addrs                 []string
+	HandshakeArgs         []string
+	AuthUsername          string
+	KeyPrefix             string
+	TLS                   bool
+	Blocking              bool
+	DryRun                bool
+	Tracing               bool
+	MaxRetries            int
+	RetryWindow           time.Duration
+	DialTimeout           time.Duration
+	KeepAlive             time.Duration
+	HealthCheckInterval   time.Duration
+	ReconnectBackoffMin   time.Duration
+	ReconnectBackoffMax   time.Duration
+	MaxReconnectAttempts  int
+	CommandTimeout        time.Duration
+	CommandBudget         time.Duration
+	SlowLogThreshold      time.Duration
+	StuckCommandThreshold time.Duration
+	MaxResponseSize       int
+	EventBufferSize       int
+	WatchBufferSize       int
+	WatchOverflow         OverflowPolicy
+	BatchSize             int
+	CustomTokenizer       bool
+	CustomArgFormatter    bool
+	CustomDialer          bool
+	CustomLogger          bool
+	CustomObserver        bool
+	CustomReconnectPolicy bool
+}
+
+// Config returns the client's effective configuration, with defaults filled
+// in for options that were not set.
+func (c *Client) Config() ClientConfig {
+	handshakeArgs := make([]string, len(c.handshakeArgs))
+	for i := range handshakeArgs {
+		handshakeArgs[i] = redacted
+	}
+
+	current := c.currentNode()
+	var addrs []string
+	for _, n := range c.nodes {
+		addrs = append(addrs, n.String())
+	}
+
+	return ClientConfig{
+		ID:                    c.id,
+		Name:                  c.name,
+		Host:                  current.host,
+		Port:                  current.port,
+		Addrs:                 addrs,
+		HandshakeArgs:         handshakeArgs,
+		AuthUsername:          c.authUsername(),
+		KeyPrefix:             c.keyPrefix,
+		TLS:                   c.tlsConfig != nil,
+		Blocking:              c.blocking,
+		DryRun:                c.recorder != nil,
+		Tracing:               c.tracing,
+		MaxRetries:            c.mainRetrier.maxRetries,
+		RetryWindow:           c.mainRetrier.retryWindow,
+		DialTimeout:           c.dialTimeout,
+		KeepAlive:             c.keepAlive,
+		HealthCheckInterval:   c.healthInterval,
+		ReconnectBackoffMin:   c.backoffPolicy.Min,
+		ReconnectBackoffMax:   c.backoffPolicy.Max,
+		MaxReconnectAttempts:  c.backoffPolicy.MaxAttempts,
+		CommandTimeout:        c.commandTimeout,
+		CommandBudget:         c.commandBudget,
+		SlowLogThreshold:      c.slowLog,
+		StuckCommandThreshold: c.stuckAfter,
+		MaxResponseSize:       maxResponseSize,
+		EventBufferSize:       len(c.events.events),
+		WatchBufferSize:       c.watchBuffer(),
+		WatchOverflow:         c.watchOverflow,
+		BatchSize:             c.batchSize(),
+		CustomTokenizer:       c.tokenizer != nil,
+		CustomArgFormatter:    c.argFormatter != nil,
+		CustomDialer:          c.dialer != nil,
+		CustomLogger:          c.log.Load() != nil,
+		CustomObserver:        c.observer != nil,
+		CustomReconnectPolicy: c.reconnectPolicy != nil,
+	}
+}
+
+func (c *Client) authUsername() string {
+	if c.auth == nil {
+		return ""
+	}
+
+	return c.auth.username
+}
@@ -0,0 +1,57 @@
+package dicedb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClient_Config(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 1, WithDryRun(), WithID("c1"), WithHandshakeArgs("user", "secret"), WithSlowLog(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	got := client.Config()
+	if got.ID != "c1" || got.Port != 1 || !got.DryRun || got.SlowLogThreshold != time.Second {
+		t.Errorf("Config() got = %+v, want id c1, port 1, dry run and a 1s slow log", got)
+	}
+	if got.BatchSize != defaultImportBatchSize || got.EventBufferSize != defaultEventBufferSize {
+		t.Errorf("Config() got = %+v, want default batch and event buffer sizes", got)
+	}
+	for _, arg := range got.HandshakeArgs {
+		if arg == "secret" {
+			t.Errorf("Config() HandshakeArgs got = %v, want them redacted", got.HandshakeArgs)
+		}
+	}
+	if len(got.HandshakeArgs) != 2 {
+		t.Errorf("Config() HandshakeArgs got %d args, want 2", len(got.HandshakeArgs))
+	}
+}
+
+func TestClient_ConfigDialTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []option
+		want time.Duration
+	}{
+		{name: "default", want: defaultDialTimeout},
+		{name: "override", opts: []option{WithDialTimeout(time.Second)}, want: time.Second},
+		{name: "zero falls back", opts: []option{WithDialTimeout(0)}, want: defaultDialTimeout},
+		{name: "negative falls back", opts: []option{WithDialTimeout(-time.Second)}, want: defaultDialTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("127.0.0.1", 1, append(tt.opts, WithDryRun())...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			if got := client.Config().DialTimeout; got != tt.want {
+				t.Errorf("Config().DialTimeout got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package dicedb
+
+import "net"
+
+// Conn returns the network connection the command connection currently runs
+// on, or nil while the client is not connected. It is meant for tuning socket
+// options and inspecting addresses: reading from or writing to it directly
+// puts the client out of step with the server. A reconnect replaces it, so
+// the returned connection may already be closed by the time it is used.
+func (c *Client) Conn() net.Conn {
+	c.mainMu.Lock()
+	defer c.mainMu.Unlock()
+
+	if c.mainWire == nil {
+		return nil
+	}
+	return c.mainWire.conn
+}
+
+// RemoteAddr returns the server address of the current command connection,
+// or nil while the client is not connected.
+func (c *Client) RemoteAddr() net.Addr {
+	conn := c.Conn()
+	if conn == nil {
+		return nil
+	}
+	return conn.RemoteAddr()
+}
@@ -0,0 +1,48 @@
+package dicedb
+
+import (
+	"net"
+	"strconv"
+	"testing"
+)
+
+func TestClient_RemoteAddr(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	want := net.JoinHostPort(server.host(), strconv.Itoa(server.port()))
+	if got := client.RemoteAddr(); got == nil || got.String() != want {
+		t.Errorf("RemoteAddr() got = %v, want %s", got, want)
+	}
+	if got := client.Conn(); got == nil || got.LocalAddr() == nil {
+		t.Errorf("Conn() got = %v, want the command connection", got)
+	}
+
+	// Reconnect replaces the connection, and Conn follows it.
+	before := client.Conn()
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+	if after := client.Conn(); after == before {
+		t.Error("Conn() after Reconnect got the old connection")
+	}
+}
+
+func TestClient_ConnNotConnected(t *testing.T) {
+	client, err := NewClient("localhost", 1, WithConnectBlocking(false))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if got := client.Conn(); got != nil {
+		t.Errorf("Conn() before connecting got = %v, want nil", got)
+	}
+	if got := client.RemoteAddr(); got != nil {
+		t.Errorf("RemoteAddr() before connecting got = %v, want nil", got)
+	}
+}
@@ -0,0 +1,40 @@
+package dicedb
+
+import (
+	"fmt"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// FireTyped fires cmd and decodes its reply into dest, which must be one of
+// *string, *int64, *bool, *[]string or *map[string]string. A Status_ERR reply
+// is returned as an error, as is a reply whose payload does not fit dest.
+func (c *Client) FireTyped(cmd *wire.Command, dest any) error {
+	resp, err := c.do(cmd)
+	if err != nil {
+		return err
+	}
+
+	return decodeResult(resp, dest)
+}
+
+func decodeResult(resp *wire.Result, dest any) error {
+	var err error
+
+	switch d := dest.(type) {
+	case *string:
+		*d, err = resp.Text()
+	case *int64:
+		*d, err = resp.Int64()
+	case *bool:
+		*d, err = resp.Bool()
+	case *[]string:
+		*d, err = resp.Strings()
+	case *map[string]string:
+		*d, err = resp.Map()
+	default:
+		return fmt.Errorf("unsupported decode destination %T", dest)
+	}
+
+	return err
+}
@@ -0,0 +1,82 @@
+package dicedb
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestDecodeResult(t *testing.T) {
+	var s string
+	var n int64
+	var b bool
+	var keys []string
+	var m map[string]string
+
+	tests := []struct {
+		name    string
+		resp    *wire.Result
+		dest    any
+		check   func() bool
+		wantErr bool
+	}{
+		{
+			name:  "string",
+			resp:  &wire.Result{Response: &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v"}}},
+			dest:  &s,
+			check: func() bool { return s == "v" },
+		},
+		{
+			name:  "int64",
+			resp:  &wire.Result{Response: &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: 7}}},
+			dest:  &n,
+			check: func() bool { return n == 7 },
+		},
+		{
+			name:  "bool",
+			resp:  &wire.Result{Response: &wire.Result_EXPIRERes{EXPIRERes: &wire.EXPIRERes{IsChanged: true}}},
+			dest:  &b,
+			check: func() bool { return b },
+		},
+		{
+			name:  "strings",
+			resp:  &wire.Result{Response: &wire.Result_KEYSRes{KEYSRes: &wire.KEYSRes{Keys: []string{"a", "b"}}}},
+			dest:  &keys,
+			check: func() bool { return slices.Equal(keys, []string{"a", "b"}) },
+		},
+		{
+			name: "map",
+			resp: &wire.Result{Response: &wire.Result_HGETALLRes{HGETALLRes: &wire.HGETALLRes{
+				Elements: []*wire.HElement{{Key: "f", Value: "v"}},
+			}}},
+			dest:  &m,
+			check: func() bool { return maps.Equal(m, map[string]string{"f": "v"}) },
+		},
+		{
+			name:    "mismatch",
+			resp:    &wire.Result{Response: &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v"}}},
+			dest:    &n,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported destination",
+			resp:    &wire.Result{},
+			dest:    &[]int{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decodeResult(tt.resp, tt.dest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !tt.check() {
+				t.Errorf("decodeResult() decoded wrong value into %T", tt.dest)
+			}
+		})
+	}
+}
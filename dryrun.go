@@ -0,0 +1,62 @@
+package dicedb
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var errDryRun = errors.New("watch is not available in dry-run mode")
+
+// WithDryRun makes the client record every command instead of sending it.
+// Commands reply with a plain OK result and can be read back with
+// RecordedCommands. A dry-run client never dials the server, so watches are
+// unavailable.
+func WithDryRun() option {
+	return func(c *Client) {
+		c.recorder = &recorder{}
+	}
+}
+
+type recorder struct {
+	mu   sync.Mutex
+	cmds []*wire.Command
+}
+
+func (r *recorder) record(cmd *wire.Command) *wire.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cmds = append(r.cmds, cmd)
+	return &wire.Result{Status: wire.Status_OK, Message: "OK"}
+}
+
+// RecordedCommands returns the commands fired so far by a client created with
+// WithDryRun, in the order they were fired. It returns nil for other clients.
+func (c *Client) RecordedCommands() []*wire.Command {
+	if c.recorder == nil {
+		return nil
+	}
+
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+
+	return append([]*wire.Command(nil), c.recorder.cmds...)
+}
+
+// Replay fires cmds, such as those returned by RecordedCommands, in order and
+// returns one result per command. Commands are pipelined in batches of the
+// size set by WithImportBatchSize. A command the server rejects does not stop
+// the ones after it, but losing the connection fails the rest of the current
+// batch with it; the next batch is sent on the restored connection.
+func (c *Client) Replay(cmds []*wire.Command) []*wire.Result {
+	results := make([]*wire.Result, 0, len(cmds))
+	for batch := range slices.Chunk(cmds, c.batchSize()) {
+		batchResults, _ := c.fireBatch(batch)
+		results = append(results, batchResults...)
+	}
+
+	return results
+}
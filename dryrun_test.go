@@ -0,0 +1,93 @@
+package dicedb
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	// Nothing listens on this port; a dry-run client must not dial it.
+	client, err := NewClient("127.0.0.1", 1, WithDryRun())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	if resp := client.Fire(&wire.Command{Cmd: "SET", Args: []string{"k", "v"}}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() status = %v, want %v", resp.Status, wire.Status_OK)
+	}
+	if err := client.MSetWithTTL([]SetEntry{{Key: "a", Value: "1", TTL: time.Second}}); err != nil {
+		t.Errorf("MSetWithTTL() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Fire(&wire.Command{Cmd: "PING"})
+		}()
+	}
+	wg.Wait()
+
+	got := client.RecordedCommands()
+	if len(got) != 12 {
+		t.Fatalf("RecordedCommands() got %d commands, want 12", len(got))
+	}
+	if got[0].Cmd != "SET" || got[1].Cmd != "SET" || got[1].Args[0] != "a" {
+		t.Errorf("RecordedCommands() got = %v, want SET k, SET a first", got[:2])
+	}
+
+	if _, err := client.WatchCh(); err == nil {
+		t.Errorf("WatchCh() error = nil, want an error in dry-run mode")
+	}
+}
+
+func TestClient_Replay(t *testing.T) {
+	recording, err := NewClient("127.0.0.1", 1, WithDryRun())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer recording.Close()
+
+	for _, cmd := range []string{"SET k v", "BAD", "GET k"} {
+		recording.FireString(cmd)
+	}
+
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "BAD" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR unknown command"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithImportBatchSize(2))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	results := client.Replay(recording.RecordedCommands())
+	want := []wire.Status{wire.Status_OK, wire.Status_ERR, wire.Status_OK}
+	if len(results) != len(want) {
+		t.Fatalf("Replay() got %d results, want %d", len(results), len(want))
+	}
+	for i, resp := range results {
+		if resp.Status != want[i] {
+			t.Errorf("Replay() result %d got = %v, want %v", i, resp.Status, want[i])
+		}
+	}
+
+	var replayed []string
+	for _, cmd := range server.commands() {
+		if cmd.Cmd != "HANDSHAKE" {
+			replayed = append(replayed, cmd.Cmd)
+		}
+	}
+	if !slices.Equal(replayed, []string{"SET", "BAD", "GET"}) {
+		t.Errorf("server got = %v, want SET, BAD, GET in order", replayed)
+	}
+}
@@ -0,0 +1,144 @@
+package dicedb
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var (
+	// ErrWrongType is returned when a command targets a key holding a value
+	// of another type.
+	ErrWrongType = errors.New("wrong type")
+	// ErrOOM is returned when the server is out of memory and refuses writes.
+	ErrOOM = errors.New("out of memory")
+	// ErrReadOnly is returned when a write reaches a read-only replica.
+	ErrReadOnly = errors.New("read only")
+
+	// ErrConnClosed is returned when a command could not be sent or its reply
+	// read because the connection was lost, or the client was not connected,
+	// closed or shutting down.
+	ErrConnClosed = errors.New("connection closed")
+	// ErrTimeout is returned when a command ran out of time, whether by
+	// WithCommandTimeout, WithTotalCommandBudget or its context's deadline.
+	ErrTimeout = errors.New("timeout")
+	// ErrInvalidCommand is returned when a command could not be parsed or
+	// encoded, so nothing was sent.
+	ErrInvalidCommand = errors.New("invalid command")
+	// ErrHandshakeFailed is returned when connecting or restoring the
+	// connection failed because the server refused the handshake or the
+	// WithAuth credentials.
+	ErrHandshakeFailed = errors.New("handshake failed")
+	// ErrMaxReconnect is returned once the reconnect policy has given up.
+	ErrMaxReconnect = errors.New("max reconnect attempts exceeded")
+)
+
+// serverErrors maps the prefix the server puts on an error message to the
+// sentinel it is reported as.
+var serverErrors = []struct {
+	prefix string
+	err    error
+}{
+	{prefix: "WRONGTYPE", err: ErrWrongType},
+	{prefix: "OOM", err: ErrOOM},
+	{prefix: "READONLY", err: ErrReadOnly},
+}
+
+// ServerError is an error reply from the server. Its message is kept as sent,
+// and errors.Is matches it against ErrWrongType, ErrOOM or ErrReadOnly when
+// the message carries the matching prefix.
+type ServerError struct {
+	Message string
+	kind    error
+}
+
+func (e *ServerError) Error() string {
+	return e.Message
+}
+
+func (e *ServerError) Unwrap() error {
+	return e.kind
+}
+
+// clientFailure builds the *ClientError for a command that failed on the
+// client's side. errors.Is matches it against kind and anything cause wraps.
+func clientFailure(kind error, message string, cause error) *ClientError {
+	return &ClientError{Message: message, kind: kind, cause: cause}
+}
+
+// ClientError is a command that failed on the client's side, without the
+// server having answered it. errors.Is matches it against ErrConnClosed,
+// ErrTimeout, ErrInvalidCommand, ErrHandshakeFailed or ErrMaxReconnect, and
+// against the error that caused it, such as the context.Canceled of a
+// cancelled FireContext.
+type ClientError struct {
+	Message string
+	kind    error
+	cause   error
+}
+
+func (e *ClientError) Error() string {
+	return e.Message
+}
+
+// result is the Status_ERR result the failed command returns.
+func (e *ClientError) result() *wire.Result {
+	return &wire.Result{Status: wire.Status_ERR, Message: e.Message}
+}
+
+func (e *ClientError) Unwrap() []error {
+	var errs []error
+	for _, err := range []error{e.kind, e.cause} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// handshakeError is a handshake or authentication refused by the server, or
+// cut short reaching it. It reads as the underlying error and also matches
+// ErrHandshakeFailed.
+type handshakeError struct {
+	err error
+}
+
+func (e *handshakeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *handshakeError) Unwrap() []error {
+	return []error{ErrHandshakeFailed, e.err}
+}
+
+// resultErr returns nil for a successful result and a *ServerError for an
+// error reply.
+func resultErr(resp *wire.Result) error {
+	if resp.Status != wire.Status_ERR {
+		return nil
+	}
+
+	return &ServerError{Message: resp.Message, kind: serverErrorKind(resp.Message)}
+}
+
+// outcomeErr returns failure, when the client made resp from one, and
+// otherwise resultErr(resp).
+func outcomeErr(resp *wire.Result, failure *ClientError) error {
+	if failure != nil {
+		return failure
+	}
+	return resultErr(resp)
+}
+
+func serverErrorKind(message string) error {
+	// Some replies put the condition after a generic ERR prefix.
+	code := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(message)), "ERR ")
+	for _, e := range serverErrors {
+		if strings.HasPrefix(code, e.prefix) {
+			return e.err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestResultErr(t *testing.T) {
+	tests := []struct {
+		message string
+		want    error
+	}{
+		{message: "WRONGTYPE Operation against a key holding the wrong kind of value", want: ErrWrongType},
+		{message: "ERR wrongtype operation against a key", want: ErrWrongType},
+		{message: "OOM command not allowed when used memory > 'maxmemory'", want: ErrOOM},
+		{message: "READONLY You can't write against a read only replica.", want: ErrReadOnly},
+		{message: "ERR unknown command", want: nil},
+		{message: "command aborted: context canceled", want: nil},
+		{message: "failed to receive response: EOF", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			err := resultErr(&wire.Result{Status: wire.Status_ERR, Message: tt.message})
+			if err == nil || err.Error() != tt.message {
+				t.Fatalf("resultErr() got = %v, want %q", err, tt.message)
+			}
+			for _, sentinel := range []error{ErrWrongType, ErrOOM, ErrReadOnly, ErrConnClosed, ErrTimeout, ErrHandshakeFailed, ErrMaxReconnect} {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v) got = %v, want %v", sentinel, got, !got)
+				}
+			}
+		})
+	}
+}
+
+func TestClientError(t *testing.T) {
+	client := &Client{commandTimeout: 50 * time.Millisecond, commandBudget: time.Second}
+	refused := &handshakeError{err: errors.New("could not complete the handshake: ERR refused")}
+	deadline, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+
+	tests := []struct {
+		name    string
+		err     *ClientError
+		message string
+		want    []error
+	}{
+		{name: "aborted", err: aborted(context.Canceled), message: "command aborted: context canceled", want: []error{context.Canceled}},
+		{name: "aborted by deadline", err: aborted(context.DeadlineExceeded), message: "command aborted: context deadline exceeded", want: []error{ErrTimeout, context.DeadlineExceeded}},
+		{name: "ctx past deadline", err: client.commandFailure(deadline, time.Time{}, &wire.WireError{Kind: wire.Timeout, Cause: os.ErrDeadlineExceeded}, sendFailure), message: "command aborted: context deadline exceeded", want: []error{ErrTimeout, context.DeadlineExceeded}},
+		{name: "command timeout", err: client.commandFailure(context.Background(), time.Time{}, &wire.WireError{Kind: wire.Timeout, Cause: os.ErrDeadlineExceeded}, sendFailure), message: "command timed out after 50ms: i/o timeout", want: []error{ErrTimeout, os.ErrDeadlineExceeded}},
+		{name: "budget", err: client.budgetFailure(&wire.WireError{Kind: wire.Timeout, Cause: os.ErrDeadlineExceeded}), message: "command budget of 1s exceeded: i/o timeout", want: []error{ErrTimeout}},
+		{name: "send terminated", err: sendFailure(&wire.WireError{Kind: wire.Terminated, Cause: io.EOF}), message: "failied to send command, connection terminated: EOF", want: []error{ErrConnClosed, io.EOF}},
+		{name: "send corrupt", err: sendFailure(&wire.WireError{Kind: wire.CorruptMessage, Cause: errors.New("invalid UTF-8")}), message: "failied to send command, corrupt message: invalid UTF-8", want: []error{ErrInvalidCommand}},
+		{name: "send timeout", err: sendFailure(&wire.WireError{Kind: wire.Timeout, Cause: os.ErrDeadlineExceeded}), message: "failed to send command, timed out: i/o timeout", want: []error{ErrTimeout}},
+		{name: "send handshake refused", err: sendFailure(&wire.WireError{Kind: wire.NotEstablished, Cause: refused}), message: "failed to send command: unrecognized error, this should be reported to DiceDB maintainers: could not complete the handshake: ERR refused", want: []error{ErrConnClosed, ErrHandshakeFailed}},
+		{name: "send reconnects exhausted", err: sendFailure(&wire.WireError{Kind: wire.NotEstablished, Cause: ErrMaxReconnect}), message: "failed to send command: unrecognized error, this should be reported to DiceDB maintainers: max reconnect attempts exceeded", want: []error{ErrConnClosed, ErrMaxReconnect}},
+		{name: "receive", err: receiveFailure(&wire.WireError{Kind: wire.Terminated, Cause: io.EOF}), message: "failed to receive response: EOF", want: []error{ErrConnClosed, io.EOF}},
+		{name: "receive corrupt", err: receiveFailure(&wire.WireError{Kind: wire.CorruptMessage, Cause: errors.New("message too large")}), message: "failed to receive response: message too large", want: []error{ErrConnClosed}},
+		{name: "receive timeout", err: receiveFailure(&wire.WireError{Kind: wire.Timeout, Cause: os.ErrDeadlineExceeded}), message: "failed to receive response, timed out: i/o timeout", want: []error{ErrTimeout}},
+		{name: "not connected", err: notConnected(), message: "client is not connected to the server yet", want: []error{ErrConnClosed}},
+		{name: "closed", err: clientClosed(), message: "could not fire command: client is closed", want: []error{ErrConnClosed}},
+		{name: "shutting down", err: shuttingDown(), message: "could not fire command: client is shutting down", want: []error{ErrConnClosed}},
+		{name: "reconnects exhausted", err: reconnectsExhausted(), message: "could not fire command: max reconnect attempts exceeded", want: []error{ErrMaxReconnect}},
+		{name: "pool closed", err: poolClosed(), message: "pool is closed", want: []error{ErrConnClosed}},
+	}
+
+	sentinels := []error{ErrWrongType, ErrOOM, ErrReadOnly, ErrConnClosed, ErrTimeout, ErrInvalidCommand, ErrHandshakeFailed, ErrMaxReconnect}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err
+			if !strings.HasPrefix(err.Error(), tt.message) {
+				t.Errorf("Error() got = %q, want it to start with %q", err, tt.message)
+			}
+			if got := err.result(); got.Status != wire.Status_ERR || got.Message != err.Message {
+				t.Errorf("result() got = %v, want a Status_ERR result with message %q", got, err.Message)
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("errors.Is(%v) got = false, want true", want)
+				}
+			}
+			for _, sentinel := range sentinels {
+				if errors.Is(err, sentinel) && !slices.Contains(tt.want, sentinel) {
+					t.Errorf("errors.Is(%v) got = true, want false", sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_FireContextCanceledErr(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out outcome
+	resp := client.intercept(ctx, &wire.Command{Cmd: "PING"}, &out)
+	if err := out.err(resp); !errors.Is(err, context.Canceled) {
+		t.Errorf("FireContext() with a cancelled ctx error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := client.do(&wire.Command{Cmd: "PING"}); err != nil {
+		t.Errorf("do() error = %v, want nil", err)
+	}
+}
+
+func TestClient_ExpireWrongType(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "EXPIRE" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "WRONGTYPE Operation against a key holding the wrong kind of value"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Expire("k", time.Second); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expire() error = %v, want %v", err, ErrWrongType)
+	}
+}
+
+func TestClient_ErrConnClosed(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.Close()
+
+	if _, err := client.Get("k"); !errors.Is(err, ErrConnClosed) {
+		t.Errorf("Get() after Close error = %v, want %v", err, ErrConnClosed)
+	}
+	var clientErr *ClientError
+	if err := client.Set("k", "v"); !errors.As(err, &clientErr) {
+		t.Errorf("Set() after Close error = %T, want *ClientError", err)
+	}
+}
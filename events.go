@@ -0,0 +1,119 @@
+package dicedb
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultEventBufferSize = 64
+
+const (
+	connCommand = "command"
+	connWatch   = "watch"
+)
+
+type EventKind int
+
+const (
+	EventConnected EventKind = iota + 1
+	EventConnectFailed
+	EventDisconnected
+	EventReconnecting
+	EventReconnected
+	EventReconnectFailed
+	EventClosed
+	EventCommandStuck
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventConnected:
+		return "connected"
+	case EventConnectFailed:
+		return "connect_failed"
+	case EventDisconnected:
+		return "disconnected"
+	case EventReconnecting:
+		return "reconnecting"
+	case EventReconnected:
+		return "reconnected"
+	case EventReconnectFailed:
+		return "reconnect_failed"
+	case EventClosed:
+		return "closed"
+	case EventCommandStuck:
+		return "command_stuck"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a connection lifecycle change. Conn is either "command" or "watch",
+// naming the connection the event happened on, and Client is the name set with
+// WithName.
+type Event struct {
+	Client string
+	Time   time.Time
+	Kind   EventKind
+	Conn   string
+	Err    error
+}
+
+// WithEventBufferSize sets how many lifecycle events RecentEvents keeps. A
+// size of zero or less disables the event log.
+func WithEventBufferSize(size int) option {
+	return func(c *Client) {
+		c.events = newEventLog(size)
+	}
+}
+
+// RecentEvents returns the most recent connection lifecycle events, oldest
+// first.
+func (c *Client) RecentEvents() []Event {
+	return c.events.snapshot()
+}
+
+// eventLog is a fixed-size ring buffer of lifecycle events.
+type eventLog struct {
+	name   string
+	mu     sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+func newEventLog(size int) *eventLog {
+	if size <= 0 {
+		return &eventLog{}
+	}
+
+	return &eventLog{events: make([]Event, size)}
+}
+
+func (l *eventLog) record(kind EventKind, conn string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.events) == 0 {
+		return
+	}
+
+	l.events[l.next] = Event{Client: l.name, Time: time.Now(), Kind: kind, Conn: conn, Err: err}
+	l.next = (l.next + 1) % len(l.events)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+func (l *eventLog) snapshot() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.full {
+		return append([]Event(nil), l.events[:l.next]...)
+	}
+
+	out := make([]Event, 0, len(l.events))
+	out = append(out, l.events[l.next:]...)
+	return append(out, l.events[:l.next]...)
+}
@@ -0,0 +1,32 @@
+package dicedb
+
+import (
+	"testing"
+)
+
+func TestEventLog(t *testing.T) {
+	log := newEventLog(3)
+	for _, kind := range []EventKind{EventConnected, EventDisconnected, EventReconnecting, EventReconnected} {
+		log.record(kind, connCommand, nil)
+	}
+
+	got := log.snapshot()
+	want := []EventKind{EventDisconnected, EventReconnecting, EventReconnected}
+	if len(got) != len(want) {
+		t.Fatalf("snapshot() len = %d, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Kind != want[i] {
+			t.Errorf("snapshot()[%d] = %s, want %s", i, e.Kind, want[i])
+		}
+	}
+}
+
+func TestEventLog_Disabled(t *testing.T) {
+	log := newEventLog(0)
+	log.record(EventConnected, connCommand, nil)
+
+	if got := log.snapshot(); len(got) != 0 {
+		t.Errorf("snapshot() = %v, want empty", got)
+	}
+}
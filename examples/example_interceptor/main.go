@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// retry fires a command again, up to attempts times in all, while it keeps
+// failing.
+func retry(attempts int, delay time.Duration) dicedb.Interceptor {
+	return func(next dicedb.Firer, cmd *wire.Command) *wire.Result {
+		resp := next.Fire(cmd)
+		for i := 1; i < attempts && resp.Status == wire.Status_ERR; i++ {
+			time.Sleep(delay)
+			resp = next.Fire(cmd)
+		}
+		return resp
+	}
+}
+
+func main() {
+	client, err := dicedb.NewClient("localhost", 7379, dicedb.WithInterceptor(retry(3, 100*time.Millisecond)))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer client.Close()
+
+	resp := client.Fire(&wire.Command{Cmd: "PING"})
+	fmt.Println(resp)
+}
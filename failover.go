@@ -0,0 +1,120 @@
+package dicedb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// node is one server address of a client made with NewClientMulti.
+type node struct {
+	host string
+	port int
+}
+
+func (n node) String() string {
+	return hostPort(n.host, n.port)
+}
+
+// NewClientMulti creates a client for a group of servers given as host:port
+// addresses. Every connection, the first one as well as each reconnect, starts
+// with the server last connected to and moves on through the list in order,
+// wrapping around, until one accepts; each attempt gets the full dial
+// timeout. Config reports the server currently in use.
+func NewClientMulti(addrs []string, opts ...option) (*Client, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no server addresses given")
+	}
+
+	nodes := make([]node, len(addrs))
+	for i, addr := range addrs {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server address %q: %w", addr, err)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid server address %q: invalid port", addr)
+		}
+		nodes[i] = node{host: host, port: port}
+	}
+
+	withNodes := func(c *Client) {
+		c.nodes = nodes
+	}
+
+	return NewClient(nodes[0].host, nodes[0].port, append([]option{withNodes}, opts...)...)
+}
+
+// currentNode returns the server the client connects to next.
+func (c *Client) currentNode() node {
+	if len(c.nodes) == 0 {
+		return node{host: c.host, port: c.port}
+	}
+	return c.nodes[c.nodeIndex.Load()]
+}
+
+// open connects to the current server and completes the handshake for mode.
+// With several servers it tries each in turn from the current one, moving on
+// when either the dial or the handshake fails, and makes the one that worked
+// current, so the watch connection and later reconnects go there too. A
+// non-zero deadline also bounds each dial, and is set on the connection before
+// the handshake. On failure
+// exactly one of dialErr and handshakeErr is set, for the last server tried.
+func (c *Client) open(mode string, timeout time.Duration, deadline time.Time) (clientWire *ClientWire, dialErr *wire.WireError, handshakeErr error) {
+	if len(c.nodes) == 0 {
+		return c.openNode(node{host: c.host, port: c.port}, mode, timeout, deadline)
+	}
+
+	start := int(c.nodeIndex.Load())
+	for i := range c.nodes {
+		idx := (start + i) % len(c.nodes)
+		clientWire, dialErr, handshakeErr = c.openNode(c.nodes[idx], mode, timeout, deadline)
+		if clientWire != nil {
+			c.nodeIndex.Store(int32(idx))
+			return clientWire, nil, nil
+		}
+
+		var err error = dialErr
+		if handshakeErr != nil {
+			err = handshakeErr
+		}
+		c.logger().Warn("failed to connect to server", "addr", c.nodes[idx], "conn", mode, "error", err)
+	}
+
+	return nil, dialErr, handshakeErr
+}
+
+func (c *Client) openNode(n node, mode string, timeout time.Duration, deadline time.Time) (*ClientWire, *wire.WireError, error) {
+	if !deadline.IsZero() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, &wire.WireError{Kind: wire.Timeout, Cause: errors.New("no time left to connect")}, nil
+		}
+		timeout = min(timeout, remaining)
+	}
+
+	clientWire, err := dialClientWire(maxResponseSize, n.host, n.port, dialOptions{
+		timeout:   timeout,
+		tlsConfig: c.tlsConfig,
+		dialer:    c.dialer,
+		keepAlive: c.keepAlive,
+	})
+	if err != nil {
+		return nil, err, nil
+	}
+
+	if !deadline.IsZero() {
+		_ = clientWire.SetDeadline(deadline)
+	}
+	if err := c.handshake(clientWire, mode); err != nil {
+		clientWire.Close()
+		return nil, nil, &handshakeError{err: err}
+	}
+
+	return clientWire, nil, nil
+}
@@ -0,0 +1,110 @@
+package dicedb
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// deadAddr returns an address nothing listens on.
+func deadAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return addr
+}
+
+func serverAddr(server *fakeServer) string {
+	return net.JoinHostPort(server.host(), strconv.Itoa(server.port()))
+}
+
+func TestNewClientMulti(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClientMulti([]string{deadAddr(t), serverAddr(server)}, WithDialTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClientMulti() error = %v", err)
+	}
+	defer client.Close()
+
+	if got := client.Config().Port; got != server.port() {
+		t.Errorf("Config().Port got = %d, want the live server's %d", got, server.port())
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() status = %s: %s", resp.Status, resp.Message)
+	}
+}
+
+func TestNewClientMulti_HandshakeRefused(t *testing.T) {
+	loading := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "HANDSHAKE" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "LOADING"}
+		}
+		return nil
+	})
+	server := newFakeServer(t, nil)
+	client, err := NewClientMulti([]string{serverAddr(loading), serverAddr(server)}, WithDialTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClientMulti() error = %v", err)
+	}
+	defer client.Close()
+
+	if got := client.Config().Port; got != server.port() {
+		t.Errorf("Config().Port got = %d, want the ready server's %d", got, server.port())
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() status = %s: %s", resp.Status, resp.Message)
+	}
+}
+
+func TestNewClientMulti_InvalidAddr(t *testing.T) {
+	tests := []struct {
+		name  string
+		addrs []string
+		want  string
+	}{
+		{name: "none", addrs: nil, want: "no server addresses given"},
+		{name: "missing port", addrs: []string{"localhost"}, want: `invalid server address "localhost"`},
+		{name: "bad port", addrs: []string{"localhost:http"}, want: "invalid port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClientMulti(tt.addrs)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewClientMulti() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_FailoverOnReconnect(t *testing.T) {
+	first := newFakeServer(t, nil)
+	second := newFakeServer(t, nil)
+	client, err := NewClientMulti([]string{serverAddr(first), serverAddr(second)}, WithDialTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClientMulti() error = %v", err)
+	}
+	defer client.Close()
+
+	if got := client.Config().Port; got != first.port() {
+		t.Fatalf("Config().Port got = %d, want the first server's %d", got, first.port())
+	}
+
+	first.Close()
+	fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+
+	if got := client.Config().Port; got != second.port() {
+		t.Errorf("Config().Port after failover got = %d, want the second server's %d", got, second.port())
+	}
+	if got := len(handshakes(second)); got == 0 {
+		t.Error("the second server never got a handshake")
+	}
+}
@@ -0,0 +1,175 @@
+package dicedb
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/internal"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// fakeServer speaks the framed protobuf protocol well enough to exercise the
+// client without a running DiceDB instance.
+type fakeServer struct {
+	t        *testing.T
+	listener net.Listener
+	handler  func(cmd *wire.Command) *wire.Result
+
+	mu      sync.Mutex
+	conns   []net.Conn
+	watches []*internal.ProtobufTCPWire
+	cmds    []*wire.Command
+}
+
+func newFakeServer(t *testing.T, handler func(cmd *wire.Command) *wire.Result) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	return startFakeServer(t, listener, handler)
+}
+
+// newTLSFakeServer is newFakeServer behind TLS, with a self-signed certificate
+// for "localhost". It returns the config a client needs to trust it.
+func newTLSFakeServer(t *testing.T, handler func(cmd *wire.Command) *wire.Result) (*fakeServer, *tls.Config) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	return startFakeServer(t, listener, handler), &tls.Config{RootCAs: roots}
+}
+
+func startFakeServer(t *testing.T, listener net.Listener, handler func(cmd *wire.Command) *wire.Result) *fakeServer {
+	s := &fakeServer{t: t, listener: listener, handler: handler}
+	go s.serve()
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *fakeServer) host() string {
+	return "127.0.0.1"
+}
+
+func (s *fakeServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	w := internal.NewProtobufTCPWire(maxResponseSize, conn)
+	for {
+		cmd := &wire.Command{}
+		if err := w.Receive(cmd); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.cmds = append(s.cmds, cmd)
+		if cmd.Cmd == "HANDSHAKE" && len(cmd.Args) > 1 && cmd.Args[1] == "watch" {
+			s.watches = append(s.watches, w)
+		}
+		s.mu.Unlock()
+
+		var res *wire.Result
+		if s.handler != nil {
+			res = s.handler(cmd)
+		}
+		if res == nil {
+			res = &wire.Result{Status: wire.Status_OK, Message: "OK"}
+		}
+
+		if err := w.Send(res); err != nil {
+			return
+		}
+	}
+}
+
+// push sends res to every connection that completed a watch handshake.
+func (s *fakeServer) push(res *wire.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, w := range s.watches {
+		_ = w.Send(res)
+	}
+}
+
+// commands returns every command received so far, in arrival order.
+func (s *fakeServer) commands() []*wire.Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*wire.Command(nil), s.cmds...)
+}
+
+// dropConns closes every accepted connection while keeping the listener up.
+func (s *fakeServer) dropConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+	s.watches = nil
+}
+
+func (s *fakeServer) Close() {
+	_ = s.listener.Close()
+	s.dropConns()
+}
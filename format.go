@@ -0,0 +1,45 @@
+package dicedb
+
+import "strconv"
+
+// ArgFormatter turns the numbers typed helpers send, such as ttls and scores,
+// into command arguments.
+type ArgFormatter interface {
+	FormatInt(n int64) string
+	FormatFloat(f float64) string
+}
+
+// WithArgFormatter replaces the formatting of numeric helper arguments. The
+// default writes integers in base 10 and floats in the shortest form that
+// parses back to the same value, independent of locale.
+func WithArgFormatter(formatter ArgFormatter) option {
+	return func(c *Client) {
+		c.argFormatter = formatter
+	}
+}
+
+type defaultArgFormatter struct{}
+
+func (defaultArgFormatter) FormatInt(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func (defaultArgFormatter) FormatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func (c *Client) formatInt(n int64) string {
+	if c.argFormatter == nil {
+		return defaultArgFormatter{}.FormatInt(n)
+	}
+
+	return c.argFormatter.FormatInt(n)
+}
+
+func (c *Client) formatFloat(f float64) string {
+	if c.argFormatter == nil {
+		return defaultArgFormatter{}.FormatFloat(f)
+	}
+
+	return c.argFormatter.FormatFloat(f)
+}
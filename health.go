@@ -0,0 +1,147 @@
+package dicedb
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// HealthStatus is a snapshot of the client's health, as returned by Health.
+// LastError is the error of the most recent failed command, whether or not
+// commands have succeeded since; compare LastErrorTime with LastSuccess to
+// tell.
+type HealthStatus struct {
+	State         State
+	LastSuccess   time.Time
+	LastError     error
+	LastErrorTime time.Time
+	Reconnects    int64
+	Watches       int
+}
+
+// health tracks command outcomes for Health.
+type health struct {
+	mu            sync.Mutex
+	lastSuccess   time.Time
+	lastError     error
+	lastErrorTime time.Time
+	reconnects    atomic.Int64
+}
+
+func (h *health) track(resp *wire.Result) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if resp.Status == wire.Status_ERR {
+		h.lastError = errors.New(resp.Message)
+		h.lastErrorTime = time.Now()
+		return
+	}
+	h.lastSuccess = time.Now()
+}
+
+// Health reports the client's state and recent command outcomes. It only reads
+// what the client already keeps track of and never talks to the server, so it
+// is cheap enough to back a health check endpoint.
+func (c *Client) Health() HealthStatus {
+	c.health.mu.Lock()
+	status := HealthStatus{
+		State:         c.State(),
+		LastSuccess:   c.health.lastSuccess,
+		LastError:     c.health.lastError,
+		LastErrorTime: c.health.lastErrorTime,
+		Reconnects:    c.health.reconnects.Load(),
+	}
+	c.health.mu.Unlock()
+
+	c.watchMu.Lock()
+	for _, group := range c.subs {
+		status.Watches += len(group)
+	}
+	c.watchMu.Unlock()
+
+	return status
+}
+
+// WithHealthCheckInterval makes the client PING the server every d in the
+// background, so a dead command connection is found and replaced between
+// commands rather than by the next one. A check is skipped while a command is
+// in flight or when one succeeded within the last interval, since either
+// already shows the connection works; an idle connection that dies is so
+// found within two intervals. Only a failure of the connection itself, not an
+// error reply, leads to a reconnect. Checks are also skipped while the client
+// is paused or not yet connected, and once the reconnect policy has given up;
+// Close stops them.
+func WithHealthCheckInterval(d time.Duration) option {
+	return func(c *Client) {
+		c.healthInterval = d
+	}
+}
+
+func (c *Client) startHealthCheck() {
+	if c.healthInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.healthInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				c.checkHealth()
+			}
+		}
+	}()
+}
+
+// checkHealth pings the server on the command connection and restores it when
+// the PING cannot be sent or answered within one interval. It never waits for
+// the connection: a busy connection is a live one.
+func (c *Client) checkHealth() {
+	if c.Paused() || c.recentlySucceeded() {
+		return
+	}
+
+	if !c.mainMu.TryLock() {
+		return
+	}
+	defer c.unlockMain()
+
+	c.drainMu.Lock()
+	draining := c.draining
+	c.drainMu.Unlock()
+	if draining || c.State() == StateClosed || c.mainWire == nil || c.backoff.exhausted() {
+		return
+	}
+
+	_ = c.mainWire.SetDeadline(time.Now().Add(c.healthInterval))
+	defer c.clearDeadline()
+
+	err := c.mainWire.Send(&wire.Command{Cmd: "PING"})
+	if err == nil {
+		var resp *wire.Result
+		if resp, err = c.mainWire.Receive(); err == nil {
+			c.health.track(resp)
+			return
+		}
+	}
+
+	c.logger().Warn("health check failed, restoring connection", "error", err)
+	if err := c.restoreMainWire(); err != nil {
+		c.logger().Warn("health check could not restore connection", "error", err)
+	}
+}
+
+func (c *Client) recentlySucceeded() bool {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	return time.Since(c.health.lastSuccess) < c.healthInterval
+}
@@ -0,0 +1,141 @@
+package dicedb
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_Health(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		switch cmd.Cmd {
+		case "BAD":
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR unknown command"}
+		case "GET.WATCH":
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Fingerprint64: 42}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if got := client.Health(); got.State != StateConnected || !got.LastSuccess.IsZero() || got.LastError != nil {
+		t.Errorf("Health() before any command got = %+v, want connected with no outcomes", got)
+	}
+
+	client.Fire(&wire.Command{Cmd: "PING"})
+	client.Fire(&wire.Command{Cmd: "BAD"})
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+	if _, err := client.OnWatch("k", func(*wire.Result) {}); err != nil {
+		t.Fatalf("OnWatch() error = %v", err)
+	}
+
+	got := client.Health()
+	if got.LastSuccess.IsZero() || got.LastError == nil || got.LastError.Error() != "ERR unknown command" {
+		t.Errorf("Health() outcomes got = %+v, want a success and the BAD error", got)
+	}
+	if got.Reconnects != 1 || got.Watches != 1 {
+		t.Errorf("Health() got = %d reconnects, %d watches, want 1, 1", got.Reconnects, got.Watches)
+	}
+}
+
+func TestClient_HealthCheckReconnects(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithHealthCheckInterval(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	server.dropConns()
+
+	// No command is fired; the health check alone has to notice the drop.
+	deadline := time.Now().Add(time.Second)
+	for len(handshakes(server)) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("health check did not restore the dropped connection")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() after health check status = %s: %s", resp.Status, resp.Message)
+	}
+}
+
+func TestClient_HealthCheckStopsOnClose(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithHealthCheckInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	client.Close()
+	time.Sleep(10 * time.Millisecond)
+	pings := len(server.commands())
+
+	time.Sleep(50 * time.Millisecond)
+	if got := len(server.commands()); got != pings {
+		t.Errorf("got %d commands after Close, want none", got-pings)
+	}
+}
+
+func TestClient_HealthCheckLeavesBusyConnection(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "SLOW" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithHealthCheckInterval(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		if resp := client.Fire(&wire.Command{Cmd: "SLOW"}); resp.Status != wire.Status_OK {
+			t.Fatalf("Fire() status = %s: %s", resp.Status, resp.Message)
+		}
+	}
+
+	if got := len(handshakes(server)); got != 1 {
+		t.Errorf("got %d handshakes, want the busy connection kept", got)
+	}
+}
+
+func TestClient_HealthCheckIgnoresErrorReply(t *testing.T) {
+	var pings atomic.Int64
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "PING" {
+			pings.Add(1)
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR busy"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithHealthCheckInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for pings.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("health check sent no PINGs")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := len(handshakes(server)); got != 1 {
+		t.Errorf("got %d handshakes, want no reconnect for an error reply", got)
+	}
+}
@@ -0,0 +1,272 @@
+package dicedb
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// ErrKeyNotFound is returned by helpers that read a key which does not exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+type ExpireFlag int
+
+const (
+	// ExpireNX sets the expiry only when the key has none.
+	ExpireNX ExpireFlag = 1 << iota
+	// ExpireXX sets the expiry only when the key already has one.
+	ExpireXX
+	// ExpireGT sets the expiry only when it is later than the current one.
+	ExpireGT
+	// ExpireLT sets the expiry only when it is earlier than the current one.
+	ExpireLT
+)
+
+func (f ExpireFlag) arg() (string, error) {
+	if bits.OnesCount(uint(f)) != 1 {
+		return "", fmt.Errorf("exactly one expire flag must be set, got %d", f)
+	}
+
+	switch f {
+	case ExpireNX:
+		return "NX", nil
+	case ExpireXX:
+		return "XX", nil
+	case ExpireGT:
+		return "GT", nil
+	case ExpireLT:
+		return "LT", nil
+	default:
+		return "", fmt.Errorf("unknown expire flag %d", f)
+	}
+}
+
+// Expire sets a ttl on key and reports whether the expiry was changed.
+func (c *Client) Expire(key string, ttl time.Duration) (bool, error) {
+	return c.expire(key, ttl)
+}
+
+// ExpireWithFlag is Expire guarded by one of the NX, XX, GT or LT conditions.
+func (c *Client) ExpireWithFlag(key string, ttl time.Duration, flag ExpireFlag) (bool, error) {
+	arg, err := flag.arg()
+	if err != nil {
+		return false, err
+	}
+
+	return c.expire(key, ttl, arg)
+}
+
+func (c *Client) expire(key string, ttl time.Duration, flags ...string) (bool, error) {
+	if ttl < time.Second {
+		return false, fmt.Errorf("ttl must be at least one second, got %s", ttl)
+	}
+
+	args := append([]string{c.key(key), c.formatInt(int64(ttl / time.Second))}, flags...)
+	resp, err := c.do(&wire.Command{Cmd: "EXPIRE", Args: args})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetEXPIRERes().GetIsChanged(), nil
+}
+
+// IncrWithExpiry increments the counter at key and makes sure it expires
+// window after its first increment, which makes it a fixed-window rate
+// limiter. INCR and EXPIRE NX are pipelined rather than wrapped in a
+// transaction; because NX never moves an existing expiry, a counter can not
+// end up without a ttl or have its window extended by later increments.
+func (c *Client) IncrWithExpiry(key string, window time.Duration) (int64, error) {
+	if window < time.Second {
+		return 0, fmt.Errorf("window must be at least one second, got %s", window)
+	}
+
+	results, failures := c.fireBatch([]*wire.Command{
+		{Cmd: "INCR", Args: []string{c.key(key)}},
+		{Cmd: "EXPIRE", Args: []string{c.key(key), c.formatInt(int64(window / time.Second)), "NX"}},
+	})
+	for i, resp := range results {
+		if err := outcomeErr(resp, failures[i]); err != nil {
+			return 0, err
+		}
+	}
+
+	return results[0].GetINCRRes().GetValue(), nil
+}
+
+// GetEx returns the value of key and sets its ttl in the same command.
+func (c *Client) GetEx(key string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		return "", fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+
+	return c.getEx(key, c.expiryArgs(ttl)...)
+}
+
+// GetExPersist returns the value of key and removes its ttl.
+func (c *Client) GetExPersist(key string) (string, error) {
+	return c.getEx(key, "PERSIST")
+}
+
+func (c *Client) getEx(key string, modifiers ...string) (string, error) {
+	resp, err := c.do(&wire.Command{Cmd: "GETEX", Args: append([]string{c.key(key)}, modifiers...)})
+	if err != nil {
+		return "", err
+	}
+
+	// The server leaves the payload out when the key does not exist.
+	if resp.GetGETEXRes() == nil {
+		return "", ErrKeyNotFound
+	}
+
+	return resp.GetGETEXRes().GetValue(), nil
+}
+
+// Rotate sets key to newValue and returns the value it replaced, reading and
+// writing in one GETSET. The old value is empty when key did not exist.
+func (c *Client) Rotate(key, newValue string) (string, error) {
+	old, _, err := c.rotate(c.key(key), newValue)
+	return old, err
+}
+
+// RotateWithBackup is Rotate that also stores the replaced value under
+// key:prev. The server has no transactions, so the backup is written with a
+// second command right after the GETSET; a reader may briefly see the new
+// value at key before the old one lands at key:prev. No backup is written when
+// key did not exist.
+func (c *Client) RotateWithBackup(key, newValue string) (string, error) {
+	key = c.key(key)
+	old, existed, err := c.rotate(key, newValue)
+	if err != nil || !existed {
+		return old, err
+	}
+
+	if _, err = c.do(&wire.Command{Cmd: "SET", Args: []string{key + ":prev", old}}); err != nil {
+		return old, fmt.Errorf("rotated %s but could not back up its old value: %w", key, err)
+	}
+
+	return old, nil
+}
+
+func (c *Client) rotate(key, newValue string) (string, bool, error) {
+	resp, err := c.do(&wire.Command{Cmd: "GETSET", Args: []string{key, newValue}})
+	if err != nil {
+		return "", false, err
+	}
+
+	// As with GETEX, the payload is left out when the key did not exist.
+	if resp.GetGETSETRes() == nil {
+		return "", false, nil
+	}
+
+	return resp.GetGETSETRes().GetValue(), true, nil
+}
+
+// SetIfExists sets key only when it already exists (SET XX) and reports
+// whether it did.
+func (c *Client) SetIfExists(key, value string) (bool, error) {
+	return c.setIf(key, value, "XX")
+}
+
+// SetIfAbsent sets key only when it does not exist yet (SET NX) and reports
+// whether it did.
+func (c *Client) SetIfAbsent(key, value string) (bool, error) {
+	return c.setIf(key, value, "NX")
+}
+
+func (c *Client) setIf(key, value, condition string) (bool, error) {
+	resp, err := c.do(&wire.Command{Cmd: "SET", Args: []string{c.key(key), value, condition}})
+	if err != nil {
+		return false, err
+	}
+
+	// A SET skipped by its condition replies with no SETRes payload.
+	return resp.GetSETRes() != nil, nil
+}
+
+// SetEntry is a key to set by MSetWithTTL. A zero TTL sets the key without an
+// expiry.
+type SetEntry struct {
+	Key   string
+	Value string
+	TTL   time.Duration
+}
+
+// MSetWithTTL sets every entry with its own ttl, pipelining one SET per entry.
+// When some of the SETs fail, the returned *BatchError lists them by their
+// index in entries.
+func (c *Client) MSetWithTTL(entries []SetEntry) error {
+	cmds := make([]*wire.Command, len(entries))
+	for i, e := range entries {
+		cmds[i] = &wire.Command{
+			Cmd:  "SET",
+			Args: append([]string{c.key(e.Key), e.Value}, c.expiryArgs(e.TTL)...),
+		}
+	}
+
+	results, failures := c.fireBatch(cmds)
+	return batchErr(results, failures)
+}
+
+// expiryArgs returns the SET modifier for ttl, preferring EX and falling back
+// to PX, rounded up to the next millisecond, when ttl is not a whole number of
+// seconds.
+func (c *Client) expiryArgs(ttl time.Duration) []string {
+	switch {
+	case ttl <= 0:
+		return nil
+	case ttl%time.Second == 0:
+		return []string{"EX", c.formatInt(int64(ttl / time.Second))}
+	default:
+		return []string{"PX", c.formatInt(int64((ttl + time.Millisecond - 1) / time.Millisecond))}
+	}
+}
+
+// GetAuto reads key with the command matching its type and returns the value
+// as a string for strings, a map[string]string for hashes, or the members in
+// rank order as a []string for sorted sets. Lists and sets are not supported
+// by the protocol yet and return an error, as does any other type; missing
+// keys return ErrKeyNotFound.
+func (c *Client) GetAuto(key string) (any, error) {
+	key = c.key(key)
+	resp, err := c.do(&wire.Command{Cmd: "TYPE", Args: []string{key}})
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ := resp.GetTYPERes().GetType(); typ {
+	case "none", "":
+		return nil, ErrKeyNotFound
+	case "string":
+		var v string
+		if err := c.FireTyped(&wire.Command{Cmd: "GET", Args: []string{key}}, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "hash":
+		var v map[string]string
+		if err := c.FireTyped(&wire.Command{Cmd: "HGETALL", Args: []string{key}}, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "zset":
+		var v []string
+		if err := c.FireTyped(&wire.Command{Cmd: "ZRANGE", Args: []string{key, "0", "-1"}}, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q for key %s", typ, key)
+	}
+}
+
+func (c *Client) exists(key string) (bool, error) {
+	resp, err := c.do(&wire.Command{Cmd: "EXISTS", Args: []string{c.key(key)}})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetEXISTSRes().GetCount() > 0, nil
+}
@@ -0,0 +1,305 @@
+package dicedb
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_ExpireWithFlag(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "EXPIRE" {
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_EXPIRERes{EXPIRERes: &wire.EXPIRERes{IsChanged: true}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		name    string
+		flag    ExpireFlag
+		wantArg string
+		wantErr bool
+	}{
+		{name: "NX", flag: ExpireNX, wantArg: "NX"},
+		{name: "XX", flag: ExpireXX, wantArg: "XX"},
+		{name: "GT", flag: ExpireGT, wantArg: "GT"},
+		{name: "LT", flag: ExpireLT, wantArg: "LT"},
+		{name: "no flag", flag: 0, wantErr: true},
+		{name: "multiple flags", flag: ExpireNX | ExpireGT, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := client.ExpireWithFlag("k", 10*time.Second, tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpireWithFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !changed {
+				t.Errorf("ExpireWithFlag() changed = false, want true")
+			}
+
+			cmds := server.commands()
+			last := cmds[len(cmds)-1]
+			if last.Cmd != "EXPIRE" || len(last.Args) != 3 || last.Args[1] != "10" || last.Args[2] != tt.wantArg {
+				t.Errorf("ExpireWithFlag() sent %v, want EXPIRE k 10 %s", last, tt.wantArg)
+			}
+		})
+	}
+}
+
+func TestClient_MSetWithTTL(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "SET" && cmd.Args[0] == "bad" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR invalid value"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	err = client.MSetWithTTL([]SetEntry{
+		{Key: "k1", Value: "v1"},
+		{Key: "bad", Value: "v2", TTL: time.Minute},
+		{Key: "k3", Value: "v3", TTL: 1500 * time.Millisecond},
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("MSetWithTTL() error = %v, want *BatchError", err)
+	}
+	if batchErr.Succeeded != 2 || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Errorf("MSetWithTTL() error = %+v, want one failure at index 1", batchErr)
+	}
+
+	var sets [][]string
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "SET" {
+			sets = append(sets, cmd.Args)
+		}
+	}
+	want := [][]string{{"k1", "v1"}, {"bad", "v2", "EX", "60"}, {"k3", "v3", "PX", "1500"}}
+	if !slices.EqualFunc(sets, want, slices.Equal[[]string]) {
+		t.Errorf("MSetWithTTL() sent %v, want %v", sets, want)
+	}
+}
+
+func TestClient_GetEx(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GETEX" && cmd.Args[0] == "k1" {
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_GETEXRes{GETEXRes: &wire.GETEXRes{Value: "v1"}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if got, err := client.GetEx("k1", 30*time.Second); err != nil || got != "v1" {
+		t.Errorf("GetEx() = %q, %v, want v1, nil", got, err)
+	}
+	if _, err := client.GetExPersist("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetExPersist() error = %v, want %v", err, ErrKeyNotFound)
+	}
+
+	var getex [][]string
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "GETEX" {
+			getex = append(getex, cmd.Args)
+		}
+	}
+	want := [][]string{{"k1", "EX", "30"}, {"missing", "PERSIST"}}
+	if !slices.EqualFunc(getex, want, slices.Equal[[]string]) {
+		t.Errorf("GETEX args = %v, want %v", getex, want)
+	}
+}
+
+func TestClient_SetIfAbsent(t *testing.T) {
+	stored := map[string]string{"taken": "v"}
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd != "SET" {
+			return nil
+		}
+		_, exists := stored[cmd.Args[0]]
+		if (cmd.Args[2] == "NX" && exists) || (cmd.Args[2] == "XX" && !exists) {
+			return &wire.Result{Status: wire.Status_OK, Message: "(nil)"}
+		}
+		stored[cmd.Args[0]] = cmd.Args[1]
+		return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_SETRes{SETRes: &wire.SETRes{}}}
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		name string
+		set  func(key, value string) (bool, error)
+		key  string
+		want bool
+	}{
+		{name: "absent on new key", set: client.SetIfAbsent, key: "new", want: true},
+		{name: "absent on existing key", set: client.SetIfAbsent, key: "taken", want: false},
+		{name: "exists on existing key", set: client.SetIfExists, key: "taken", want: true},
+		{name: "exists on missing key", set: client.SetIfExists, key: "missing", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.set(tt.key, "v2")
+			if err != nil || got != tt.want {
+				t.Errorf("got = %v, %v, want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_IncrWithExpiry(t *testing.T) {
+	var count int64
+	var expiries []string
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		switch cmd.Cmd {
+		case "INCR":
+			count++
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: count}}}
+		case "EXPIRE":
+			expiries = append(expiries, strings.Join(cmd.Args, " "))
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for want := int64(1); want <= 2; want++ {
+		got, err := client.IncrWithExpiry("hits", time.Minute)
+		if err != nil || got != want {
+			t.Errorf("IncrWithExpiry() got = %v, %v, want %v, nil", got, err, want)
+		}
+	}
+
+	if len(expiries) != 2 || expiries[0] != "hits 60 NX" {
+		t.Errorf("EXPIRE args got = %v, want [hits 60 NX] twice", expiries)
+	}
+
+	if _, err := client.IncrWithExpiry("hits", time.Millisecond); err == nil {
+		t.Errorf("IncrWithExpiry() with a sub-second window got nil error")
+	}
+}
+
+func TestClient_GetAuto(t *testing.T) {
+	types := map[string]string{"s": "string", "h": "hash", "z": "zset", "l": "list"}
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		ok := func(res *wire.Result) *wire.Result {
+			res.Status, res.Message = wire.Status_OK, "OK"
+			return res
+		}
+		switch cmd.Cmd {
+		case "TYPE":
+			typ, found := types[cmd.Args[0]]
+			if !found {
+				typ = "none"
+			}
+			return ok(&wire.Result{Response: &wire.Result_TYPERes{TYPERes: &wire.TYPERes{Type: typ}}})
+		case "GET":
+			return ok(&wire.Result{Response: &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v"}}})
+		case "HGETALL":
+			return ok(&wire.Result{Response: &wire.Result_HGETALLRes{HGETALLRes: &wire.HGETALLRes{Elements: []*wire.HElement{{Key: "f", Value: "v"}}}}})
+		case "ZRANGE":
+			return ok(&wire.Result{Response: &wire.Result_ZRANGERes{ZRANGERes: &wire.ZRANGERes{Elements: []*wire.ZElement{{Member: "a"}, {Member: "b"}}}}})
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		key     string
+		want    any
+		wantErr bool
+	}{
+		{key: "s", want: "v"},
+		{key: "h", want: map[string]string{"f": "v"}},
+		{key: "z", want: []string{"a", "b"}},
+		{key: "l", wantErr: true},
+		{key: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := client.GetAuto(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAuto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAuto() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := client.GetAuto("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetAuto() on a missing key error = %v, want %v", err, ErrKeyNotFound)
+	}
+}
+
+func TestClient_RotateWithBackup(t *testing.T) {
+	stored := map[string]string{"cfg": "v1"}
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		switch cmd.Cmd {
+		case "GETSET":
+			old, existed := stored[cmd.Args[0]]
+			stored[cmd.Args[0]] = cmd.Args[1]
+			if !existed {
+				return nil
+			}
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_GETSETRes{GETSETRes: &wire.GETSETRes{Value: old}}}
+		case "SET":
+			stored[cmd.Args[0]] = cmd.Args[1]
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	old, err := client.RotateWithBackup("cfg", "v2")
+	if err != nil || old != "v1" {
+		t.Errorf("RotateWithBackup() got = %q, %v, want v1, nil", old, err)
+	}
+	if stored["cfg"] != "v2" || stored["cfg:prev"] != "v1" {
+		t.Errorf("stored got = %v, want cfg v2 and cfg:prev v1", stored)
+	}
+
+	old, err = client.RotateWithBackup("new", "v1")
+	if err != nil || old != "" {
+		t.Errorf("RotateWithBackup() on a new key got = %q, %v, want empty, nil", old, err)
+	}
+	if _, ok := stored["new:prev"]; ok {
+		t.Errorf("RotateWithBackup() on a new key wrote a backup")
+	}
+}
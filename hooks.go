@@ -0,0 +1,49 @@
+package dicedb
+
+// WithOnReconnect registers fn to run after every successful reconnect, with
+// conn naming the connection that was restored, "command" or "watch".
+//
+// fn never runs while the client holds a connection lock, so it may fire
+// commands of its own. A command connection reconnect happens while some Fire
+// is retrying its command; fn runs on that caller's goroutine after the
+// retried command has completed and before that Fire returns. A watch
+// connection reconnect runs fn on the watch goroutine once the active watches
+// have been fired again, before it reads from the new connection.
+func WithOnReconnect(fn func(c *Client, conn string)) option {
+	return func(c *Client) {
+		c.onReconnect = fn
+	}
+}
+
+// WithOnReconnectError registers fn to run after every failed reconnect
+// attempt, under the same guarantees as WithOnReconnect.
+func WithOnReconnectError(fn func(conn string, err error)) option {
+	return func(c *Client) {
+		c.onReconnectError = fn
+	}
+}
+
+// reconnectHook returns the hook to run for a reconnect outcome, or nil when
+// none is registered for it.
+func (c *Client) reconnectHook(conn string, err error) func() {
+	switch {
+	case err == nil && c.onReconnect != nil:
+		return func() { c.onReconnect(c, conn) }
+	case err != nil && c.onReconnectError != nil:
+		return func() { c.onReconnectError(conn, err) }
+	}
+
+	return nil
+}
+
+// unlockMain releases mainMu and then runs the hooks for the reconnects made
+// while this caller held it.
+func (c *Client) unlockMain() {
+	hooks := c.mainHooks
+	c.mainHooks = nil
+	c.mainMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
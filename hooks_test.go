@@ -0,0 +1,113 @@
+package dicedb
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_OnReconnect(t *testing.T) {
+	server := newFakeServer(t, nil)
+
+	var reconnects []string
+	var replayed *wire.Result
+	client, err := NewClient(server.host(), server.port(), WithOnReconnect(func(c *Client, conn string) {
+		reconnects = append(reconnects, conn)
+		// Firing from the hook must not deadlock on the connection lock.
+		replayed = c.Fire(&wire.Command{Cmd: "PING"})
+	}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	server.dropConns()
+	fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+
+	if len(reconnects) != 1 || reconnects[0] != connCommand {
+		t.Fatalf("OnReconnect calls got = %v, want [%s]", reconnects, connCommand)
+	}
+	if replayed == nil || replayed.Status != wire.Status_OK {
+		t.Errorf("Fire() from OnReconnect got = %v, want OK", replayed)
+	}
+}
+
+func TestClient_OnReconnectError(t *testing.T) {
+	var refuse atomic.Bool
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "HANDSHAKE" && refuse.Load() {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR refused"}
+		}
+		return nil
+	})
+
+	var failures []error
+	client, err := NewClient(server.host(), server.port(), WithOnReconnectError(func(conn string, err error) {
+		if conn != connCommand {
+			t.Errorf("OnReconnectError conn got = %s, want %s", conn, connCommand)
+		}
+		failures = append(failures, err)
+	}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	refuse.Store(true)
+	if err := client.Reconnect(); err == nil {
+		t.Fatalf("Reconnect() error = nil, want a handshake failure")
+	}
+
+	if len(failures) != 1 || failures[0] == nil {
+		t.Errorf("OnReconnectError calls got = %v, want one error", failures)
+	}
+}
+
+func TestClient_OnReconnectWatch(t *testing.T) {
+	var watches atomic.Int64
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET.WATCH" {
+			watches.Add(1)
+			return &wire.Result{Status: wire.Status_OK, Fingerprint64: 42}
+		}
+		return nil
+	})
+
+	hooks := make(chan string, 4)
+	var replayedFirst atomic.Bool
+	client, err := NewClient(server.host(), server.port(), WithOnReconnect(func(c *Client, conn string) {
+		if conn == connWatch {
+			replayedFirst.Store(watches.Load() == 2)
+		}
+		hooks <- conn
+	}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.WatchCommand(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k"}}); err != nil {
+		t.Fatalf("WatchCommand() error = %v", err)
+	}
+
+	// Replaying the watch reconnects the command connection too, and that
+	// Fire runs its hook before the watch connection's hook.
+	server.dropConns()
+	var got []string
+	for range 2 {
+		select {
+		case conn := <-hooks:
+			got = append(got, conn)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("OnReconnect calls got = %v, want [%s %s]", got, connCommand, connWatch)
+		}
+	}
+	if got[0] != connCommand || got[1] != connWatch {
+		t.Errorf("OnReconnect calls got = %v, want [%s %s]", got, connCommand, connWatch)
+	}
+	if !replayedFirst.Load() {
+		t.Errorf("OnReconnect for the watch connection ran before the watch was replayed")
+	}
+}
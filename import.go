@@ -0,0 +1,95 @@
+package dicedb
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+const defaultImportBatchSize = 128
+
+// WithImportBatchSize sets how many commands Import and FireStream pipeline per
+// round trip.
+func WithImportBatchSize(size int) option {
+	return func(c *Client) {
+		c.importBatchSize = size
+	}
+}
+
+func (c *Client) batchSize() int {
+	if c.importBatchSize <= 0 {
+		return defaultImportBatchSize
+	}
+	return c.importBatchSize
+}
+
+// Import reads newline-delimited commands from r, parses each one like
+// FireString does and pipelines them to the server in batches, skipping blank
+// lines. It returns the number of commands that succeeded. On the first
+// failing command, Import finishes the batch it is part of and returns an
+// error naming the line it was read from.
+func (c *Client) Import(r io.Reader) (int64, error) {
+	batchSize := c.batchSize()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxResponseSize)
+
+	var applied int64
+	var cmds []*wire.Command
+	var lines []int
+
+	flush := func() error {
+		results, failures := c.fireBatch(cmds)
+		var firstErr error
+		for i, resp := range results {
+			if err := outcomeErr(resp, failures[i]); err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("line %d: %w", lines[i], err)
+				}
+				continue
+			}
+			applied++
+		}
+
+		cmds, lines = cmds[:0], lines[:0]
+		return firstErr
+	}
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		cmd, args, err := c.tokenize(text)
+		if err != nil {
+			if ferr := flush(); ferr != nil {
+				return applied, ferr
+			}
+			return applied, fmt.Errorf("line %d: could not parse command: %w", line, err)
+		}
+
+		cmds = append(cmds, &wire.Command{Cmd: cmd, Args: args})
+		lines = append(lines, line)
+		if len(cmds) == batchSize {
+			if err := flush(); err != nil {
+				return applied, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return applied, err
+	}
+
+	if err := scanner.Err(); err != nil {
+		return applied, fmt.Errorf("line %d: could not read input: %w", line+1, err)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,35 @@
+package dicedb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_Import(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "BAD" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR unknown command"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithImportBatchSize(2))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	n, err := client.Import(strings.NewReader("SET k1 v1\n\nSET k2 v2\nSET k3 v3\n"))
+	if err != nil || n != 3 {
+		t.Fatalf("Import() = %d, %v, want 3, nil", n, err)
+	}
+
+	n, err = client.Import(strings.NewReader("SET k1 v1\nBAD\nSET k2 v2\nSET k3 v3\n"))
+	if n != 1 {
+		t.Errorf("Import() applied = %d, want 1", n)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("Import() error = %v, want error for line 2", err)
+	}
+}
@@ -0,0 +1,90 @@
+package dicedb
+
+import (
+	"context"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// Firer sends a command and returns its result. *Client and *Pool both
+// implement it. Code that only fires commands can depend on Firer instead of
+// *Client, so its tests can pass a fake in place of a client that needs a
+// server to dial.
+type Firer interface {
+	Fire(cmd *wire.Command) *wire.Result
+}
+
+// StringFirer is a Firer that also accepts commands as strings, as FireString
+// does. *Client implements it.
+type StringFirer interface {
+	Firer
+	FireString(cmdStr string) *wire.Result
+}
+
+// Interceptor wraps a command. It may inspect or rewrite cmd, call next.Fire
+// any number of times, and return any result in place of the real one.
+type Interceptor func(next Firer, cmd *wire.Command) *wire.Result
+
+// WithInterceptor adds fn around every command sent with Fire, FireContext or
+// a helper built on them. Interceptors compose in registration order, the
+// first one registered being the outermost. Batches and streams are not
+// intercepted.
+func WithInterceptor(fn Interceptor) option {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors, fn)
+	}
+}
+
+type firerFunc func(cmd *wire.Command) *wire.Result
+
+func (f firerFunc) Fire(cmd *wire.Command) *wire.Result {
+	return f(cmd)
+}
+
+// outcome keeps what the innermost fire of one intercepted call returned, so
+// the caller can tell whether the result that came out of the interceptors is
+// one the client made from a *ClientError.
+type outcome struct {
+	resp    *wire.Result
+	failure *ClientError
+}
+
+// err returns the error for resp, the result the call returned: the
+// *ClientError it was made from if it is the last one fire returned, and
+// otherwise resultErr(resp).
+func (o *outcome) err(resp *wire.Result) error {
+	if resp == o.resp {
+		return outcomeErr(resp, o.failure)
+	}
+	return resultErr(resp)
+}
+
+// intercept runs cmd through the interceptors and then fire, with ctx
+// carried along to the innermost call. When out is not nil, it records what
+// that call returned.
+func (c *Client) intercept(ctx context.Context, cmd *wire.Command, out *outcome) *wire.Result {
+	var next Firer = firerFunc(func(cmd *wire.Command) *wire.Result {
+		resp, failure := c.fire(ctx, cmd)
+		if out != nil {
+			out.resp, out.failure = resp, failure
+		}
+		return resp
+	})
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := c.interceptors[i], next
+		next = firerFunc(func(cmd *wire.Command) *wire.Result {
+			return interceptor(inner, cmd)
+		})
+	}
+
+	return next.Fire(cmd)
+}
+
+// do fires cmd as Fire does and returns its result together with its error,
+// if it failed. The helpers built on Fire use it so that a command that failed
+// on the client's side is reported as the *ClientError it came from.
+func (c *Client) do(cmd *wire.Command) (*wire.Result, error) {
+	var out outcome
+	resp := c.intercept(context.Background(), cmd, &out)
+	return resp, out.err(resp)
+}
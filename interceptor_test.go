@@ -0,0 +1,145 @@
+package dicedb
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_WithInterceptor(t *testing.T) {
+	var order []string
+	trace := func(name string) Interceptor {
+		return func(next Firer, cmd *wire.Command) *wire.Result {
+			order = append(order, name+" before "+cmd.Cmd)
+			resp := next.Fire(cmd)
+			order = append(order, name+" after "+cmd.Cmd)
+			return resp
+		}
+	}
+	rename := func(next Firer, cmd *wire.Command) *wire.Result {
+		return next.Fire(&wire.Command{Cmd: "ECHO", Args: []string{cmd.Cmd}})
+	}
+
+	client, err := NewClient("127.0.0.1", 1, WithDryRun(),
+		WithInterceptor(trace("outer")), WithInterceptor(trace("inner")), WithInterceptor(rename))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	client.Fire(&wire.Command{Cmd: "PING"})
+
+	want := []string{"outer before PING", "inner before PING", "inner after PING", "outer after PING"}
+	if !slices.Equal(order, want) {
+		t.Errorf("interceptor order got = %v, want %v", order, want)
+	}
+
+	got := client.RecordedCommands()
+	if len(got) != 1 || got[0].Cmd != "ECHO" || !slices.Equal(got[0].Args, []string{"PING"}) {
+		t.Errorf("sent = %v, want the command rewritten by the innermost interceptor", got)
+	}
+}
+
+func TestClient_InterceptorRetry(t *testing.T) {
+	failures := 2
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET" && failures > 0 {
+			failures--
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR try again"}
+		}
+		return nil
+	})
+
+	retry := func(next Firer, cmd *wire.Command) *wire.Result {
+		resp := next.Fire(cmd)
+		for i := 0; i < 3 && resp.Status == wire.Status_ERR; i++ {
+			resp = next.Fire(cmd)
+		}
+		return resp
+	}
+	client, err := NewClient(server.host(), server.port(), WithInterceptor(retry))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if resp := client.Fire(&wire.Command{Cmd: "GET", Args: []string{"k"}}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() with a retrying interceptor got = %v, want OK", resp)
+	}
+}
+
+func TestClient_InterceptorErrors(t *testing.T) {
+	passThrough := func(next Firer, cmd *wire.Command) *wire.Result {
+		return next.Fire(cmd)
+	}
+	replace := func(next Firer, cmd *wire.Command) *wire.Result {
+		resp := next.Fire(cmd)
+		return &wire.Result{Status: resp.Status, Message: resp.Message}
+	}
+
+	tests := []struct {
+		name        string
+		interceptor Interceptor
+		clientErr   bool
+	}{
+		{name: "passed through", interceptor: passThrough, clientErr: true},
+		{name: "replaced", interceptor: replace, clientErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, nil)
+			client, err := NewClient(server.host(), server.port(), WithInterceptor(tt.interceptor))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			client.Close()
+
+			_, err = client.Get("k")
+			var clientErr *ClientError
+			if got := errors.As(err, &clientErr); got != tt.clientErr {
+				t.Errorf("Get() error = %T %v, want *ClientError %v", err, err, tt.clientErr)
+			}
+			if got := errors.Is(err, ErrConnClosed); got != tt.clientErr {
+				t.Errorf("errors.Is(ErrConnClosed) got = %v, want %v", got, tt.clientErr)
+			}
+		})
+	}
+}
+
+// fakeFirer answers every command with its name, standing in for a client.
+type fakeFirer struct {
+	fired []string
+}
+
+func (f *fakeFirer) Fire(cmd *wire.Command) *wire.Result {
+	f.fired = append(f.fired, cmd.Cmd)
+	return &wire.Result{Status: wire.Status_OK, Message: cmd.Cmd}
+}
+
+func (f *fakeFirer) FireString(cmdStr string) *wire.Result {
+	cmd, args, err := shellTokenizer(cmdStr)
+	if err != nil {
+		return &wire.Result{Status: wire.Status_ERR, Message: err.Error()}
+	}
+	return f.Fire(&wire.Command{Cmd: cmd, Args: args})
+}
+
+func TestStringFirer(t *testing.T) {
+	var _ StringFirer = (*Client)(nil)
+	var _ Firer = (*Pool)(nil)
+
+	ping := func(f StringFirer) string {
+		return f.FireString("PING").Message
+	}
+
+	fake := &fakeFirer{}
+	if got := ping(fake); got != "PING" {
+		t.Errorf("FireString() got = %s, want PING", got)
+	}
+	if !slices.Equal(fake.fired, []string{"PING"}) {
+		t.Errorf("Fire() calls got = %v, want [PING]", fake.fired)
+	}
+}
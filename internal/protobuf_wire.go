@@ -6,6 +6,7 @@ package internal
 import (
 	"github.com/dicedb/dicedb-go/wire"
 	"net"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -23,7 +24,7 @@ func NewProtobufTCPWire(maxMsgSize int, conn net.Conn) *ProtobufTCPWire {
 func (w *ProtobufTCPWire) Send(msg proto.Message) *wire.WireError {
 	buffer, err := proto.Marshal(msg)
 	if err != nil {
-		w.tcpWire.Close()
+		// Nothing was written, so the connection is still in step.
 		return &wire.WireError{Kind: wire.CorruptMessage, Cause: err}
 	}
 
@@ -45,6 +46,10 @@ func (w *ProtobufTCPWire) Receive(dst proto.Message) *wire.WireError {
 	return nil
 }
 
-func (w *ProtobufTCPWire) Close() {
-	w.tcpWire.Close()
+func (w *ProtobufTCPWire) SetDeadline(t time.Time) error {
+	return w.tcpWire.SetDeadline(t)
+}
+
+func (w *ProtobufTCPWire) Close() error {
+	return w.tcpWire.Close()
 }
@@ -10,10 +10,12 @@ import (
 	"fmt"
 	"github.com/dicedb/dicedb-go/wire"
 	"io"
-	"log/slog"
 	"net"
+	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -27,7 +29,9 @@ const (
 )
 
 type TCPWire struct {
-	status     Status
+	// status is read and written by Send, Receive and Close, which may run
+	// on different goroutines.
+	status     atomic.Int32
 	maxMsgSize int
 	readMu     sync.Mutex
 	reader     *bufio.Reader
@@ -36,19 +40,21 @@ type TCPWire struct {
 }
 
 func NewTCPWire(maxMsgSize int, conn net.Conn) *TCPWire {
-	return &TCPWire{
-		status:     Open,
+	w := &TCPWire{
 		maxMsgSize: maxMsgSize,
 		conn:       conn,
 		reader:     bufio.NewReader(conn),
 	}
+	w.status.Store(int32(Open))
+
+	return w
 }
 
 func (w *TCPWire) Send(msg []byte) *wire.WireError {
 	w.writeMu.Lock()
 	defer w.writeMu.Unlock()
 
-	if w.status == Closed {
+	if Status(w.status.Load()) == Closed {
 		return &wire.WireError{Kind: wire.Terminated, Cause: errors.New("trying to use closed wire")}
 	}
 
@@ -94,18 +100,22 @@ func (w *TCPWire) Receive() ([]byte, *wire.WireError) {
 	return buffer, nil
 }
 
-func (w *TCPWire) Close() {
-	if w.status == Closed {
-		return
-	}
-
-	w.status = Closed
-	err := w.conn.Close()
-	if err != nil {
-		slog.Warn("error closing network connection", "error", err)
+// SetDeadline bounds every Send and Receive until t; a zero t removes the
+// bound. Once it passes, they fail with a Timeout error and close the wire,
+// since a reply arriving later would be read as the answer to the next
+// command.
+func (w *TCPWire) SetDeadline(t time.Time) error {
+	return w.conn.SetDeadline(t)
+}
 
-		return
+// Close closes the connection and returns the error from doing so. Closing a
+// closed wire does nothing and returns nil.
+func (w *TCPWire) Close() error {
+	if !w.status.CompareAndSwap(int32(Open), int32(Closed)) {
+		return nil
 	}
+
+	return w.conn.Close()
 }
 
 func (w *TCPWire) readPrefix() (uint32, *wire.WireError) {
@@ -121,6 +131,9 @@ func (w *TCPWire) readPrefix() (uint32, *wire.WireError) {
 		}
 
 		lastErr = err
+		if errors.Is(err, os.ErrDeadlineExceeded) || disconnected(err) {
+			break
+		}
 
 		// Retry only on timeout or temporary errors
 		var opErr *net.OpError
@@ -137,12 +150,15 @@ func (w *TCPWire) readPrefix() (uint32, *wire.WireError) {
 
 	// Classify the final error
 	switch {
+	case errors.Is(lastErr, os.ErrDeadlineExceeded):
+		w.Close()
+		return 0, &wire.WireError{Kind: wire.Timeout, Cause: lastErr}
 	case errors.Is(lastErr, io.EOF):
-		return 0, &wire.WireError{Kind: wire.Empty, Cause: lastErr}
-	case errors.Is(lastErr, io.ErrUnexpectedEOF):
+		// The server closed the connection between messages; close our side
+		// too so the next Send fails as Terminated and reconnects.
 		w.Close()
-		return 0, &wire.WireError{Kind: wire.Terminated, Cause: lastErr}
-	case strings.Contains(lastErr.Error(), "use of closed network connection"):
+		return 0, &wire.WireError{Kind: wire.Empty, Cause: lastErr}
+	case errors.Is(lastErr, io.ErrUnexpectedEOF), disconnected(lastErr):
 		w.Close()
 		return 0, &wire.WireError{Kind: wire.Terminated, Cause: lastErr}
 	case func() bool {
@@ -172,6 +188,9 @@ func (w *TCPWire) readMessage(size uint32) ([]byte, *wire.WireError) {
 		}
 
 		lastErr = err
+		if errors.Is(err, os.ErrDeadlineExceeded) || disconnected(err) {
+			break
+		}
 
 		// Retry only on timeout or temporary errors or EOF in case of partial write
 		var opErr *net.OpError
@@ -188,25 +207,25 @@ func (w *TCPWire) readMessage(size uint32) ([]byte, *wire.WireError) {
 
 	// Classify the final error
 	switch {
+	case errors.Is(lastErr, os.ErrDeadlineExceeded):
+		w.Close()
+		return buffer, &wire.WireError{Kind: wire.Timeout, Cause: lastErr}
 	case errors.Is(lastErr, io.EOF):
-		w.status = Closed
+		w.status.Store(int32(Closed))
 		return buffer, &wire.WireError{Kind: wire.CorruptMessage, Cause: lastErr}
-	case errors.Is(lastErr, io.ErrUnexpectedEOF):
-		w.status = Closed
-		return buffer, &wire.WireError{Kind: wire.Terminated, Cause: lastErr}
-	case strings.Contains(lastErr.Error(), "use of closed network connection"):
-		w.status = Closed
+	case errors.Is(lastErr, io.ErrUnexpectedEOF), disconnected(lastErr):
+		w.status.Store(int32(Closed))
 		return buffer, &wire.WireError{Kind: wire.Terminated, Cause: lastErr}
 	case func() bool {
 		var opErr *net.OpError
 		return errors.As(lastErr, &opErr) && (opErr.Timeout() || opErr.Temporary())
 	}():
 		// This case was already checked during retries, but it falls back here if it's a fatal error
-		w.status = Closed
+		w.status.Store(int32(Closed))
 		return buffer, &wire.WireError{Kind: wire.Terminated, Cause: lastErr}
 	default:
 		// Handle other unknown error types by marking the status as closed
-		w.status = Closed
+		w.status.Store(int32(Closed))
 		return buffer, &wire.WireError{Kind: wire.Terminated, Cause: lastErr}
 	}
 }
@@ -229,15 +248,20 @@ func (w *TCPWire) write(buffer []byte) *wire.WireError {
 
 		if err != nil && !errors.Is(err, io.ErrShortWrite) {
 			lastRetryableErr = err
-			if errors.Is(err, io.ErrClosedPipe) {
-				w.status = Closed
+			if disconnected(err) {
+				w.status.Store(int32(Closed))
 				return &wire.WireError{Kind: wire.Terminated, Cause: err}
 			}
 
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				w.Close()
+				return &wire.WireError{Kind: wire.Timeout, Cause: err}
+			}
+
 			var opErr *net.OpError
 			if errors.As(err, &opErr) && (opErr.Timeout() || opErr.Temporary()) {
 				if backoffRetries > maxBackoffRetries {
-					w.status = Closed
+					w.status.Store(int32(Closed))
 					return &wire.WireError{
 						Kind:  wire.Terminated,
 						Cause: fmt.Errorf("max backoff retries reached: %w", lastRetryableErr),
@@ -250,13 +274,13 @@ func (w *TCPWire) write(buffer []byte) *wire.WireError {
 				continue
 			}
 
-			w.status = Closed
+			w.status.Store(int32(Closed))
 			return &wire.WireError{Kind: wire.Terminated, Cause: err}
 		}
 
 		if isPartial {
 			if partialWriteRetries >= maxPartialWriteRetries {
-				w.status = Closed
+				w.status.Store(int32(Closed))
 				return &wire.WireError{
 					Kind:  wire.Terminated,
 					Cause: fmt.Errorf("max partial write retries reached: %w", err),
@@ -271,6 +295,27 @@ func (w *TCPWire) write(buffer []byte) *wire.WireError {
 	return nil
 }
 
+// disconnected reports whether err means the connection is gone for good, as
+// opposed to a timeout or a temporary failure worth retrying. ECONNRESET counts
+// as temporary to the net package, so it has to be checked first.
+func disconnected(err error) bool {
+	switch {
+	case errors.Is(err, net.ErrClosed),
+		errors.Is(err, io.ErrClosedPipe),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE):
+		return true
+	}
+
+	// Errors that crossed a process or library boundary may only keep their
+	// message.
+	msg := err.Error()
+	return strings.Contains(msg, "use of closed network connection") ||
+		strings.Contains(msg, "connection reset by peer") ||
+		strings.Contains(msg, "broken pipe")
+}
+
 func prefix(msgSize int, buffer []byte) {
 	binary.BigEndian.PutUint32(buffer[:prefixSize], uint32(msgSize))
 }
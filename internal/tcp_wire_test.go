@@ -2,10 +2,16 @@ package internal
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
+	"net"
+	"os"
+	"syscall"
 	"testing"
 
 	"github.com/dicedb/dicedb-go/mock"
+	"github.com/dicedb/dicedb-go/wire"
 	"go.uber.org/mock/gomock"
 )
 
@@ -97,3 +103,102 @@ func TestReceive(t *testing.T) {
 		t.Errorf("Receive() captured = %v, want %v", buffer, want)
 	}
 }
+
+func opError(op string, err error) error {
+	return &net.OpError{Op: op, Net: "tcp", Err: os.NewSyscallError(op, err)}
+}
+
+func TestReceiveDisconnect(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want wire.ErrKind
+	}{
+		{name: "closed", err: &net.OpError{Op: "read", Net: "tcp", Err: net.ErrClosed}, want: wire.Terminated},
+		{name: "reset", err: opError("read", syscall.ECONNRESET), want: wire.Terminated},
+		{name: "broken pipe", err: opError("read", syscall.EPIPE), want: wire.Terminated},
+		{name: "reset message only", err: errors.New("read tcp 10.0.0.1:7379: connection reset by peer"), want: wire.Terminated},
+		{name: "eof", err: io.EOF, want: wire.Empty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockConn := mock.NewMockConn(ctrl)
+			mockConn.EXPECT().Read(gomock.Any()).Times(1).Return(0, tt.err)
+			mockConn.EXPECT().Close().Times(1)
+			tcpWire := NewTCPWire(50, mockConn)
+
+			// act
+			_, err := tcpWire.Receive()
+
+			// assert
+			if err == nil || err.Kind != tt.want {
+				t.Fatalf("Receive() error = %v, want kind %v", err, tt.want)
+			}
+
+			// A closed wire makes the next Send fail as Terminated, which is
+			// what the client reconnects on.
+			if err := tcpWire.Send([]byte{1}); err == nil || err.Kind != wire.Terminated {
+				t.Errorf("Send() after disconnect error = %v, want kind %v", err, wire.Terminated)
+			}
+		})
+	}
+}
+
+func TestSendDisconnect(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "closed", err: &net.OpError{Op: "write", Net: "tcp", Err: net.ErrClosed}},
+		{name: "reset", err: opError("write", syscall.ECONNRESET)},
+		{name: "broken pipe", err: opError("write", syscall.EPIPE)},
+		{name: "wrapped", err: fmt.Errorf("flush: %w", opError("write", syscall.EPIPE))},
+		{name: "closed pipe", err: io.ErrClosedPipe},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockConn := mock.NewMockConn(ctrl)
+			mockConn.EXPECT().Write(gomock.Any()).Times(1).Return(0, tt.err)
+			tcpWire := NewTCPWire(50, mockConn)
+
+			// act
+			err := tcpWire.Send([]byte{1})
+
+			// assert
+			if err == nil || err.Kind != wire.Terminated {
+				t.Errorf("Send() error = %v, want kind %v", err, wire.Terminated)
+			}
+		})
+	}
+}
+
+func TestCloseWhileReceiving(t *testing.T) {
+	// arrange
+	client, server := net.Pipe()
+	defer server.Close()
+	tcpWire := NewTCPWire(50, client)
+	done := make(chan *wire.WireError)
+
+	// act
+	go func() {
+		_, err := tcpWire.Receive()
+		done <- err
+	}()
+	tcpWire.Close()
+
+	// assert
+	if err := <-done; err == nil {
+		t.Errorf("Receive() on a closed wire error = nil, want an error")
+	}
+	if err := tcpWire.Send([]byte{1}); err == nil || err.Kind != wire.Terminated {
+		t.Errorf("Send() after Close error = %v, want kind %v", err, wire.Terminated)
+	}
+}
@@ -1,9 +1,14 @@
 package internal
 
-import "github.com/dicedb/dicedb-go/wire"
+import (
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
 
 type Wire interface {
 	Send([]byte) *wire.WireError
 	Receive() ([]byte, *wire.WireError)
-	Close()
+	SetDeadline(t time.Time) error
+	Close() error
 }
@@ -0,0 +1,78 @@
+package dicedb
+
+// Logger receives the client's diagnostics. Its methods take a message
+// followed by alternating keys and values, so a *slog.Logger satisfies it, and
+// adapters for zap or logrus take a few lines.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// WithLogger routes the client's diagnostics to l. Without it the client logs
+// nothing.
+func WithLogger(l Logger) option {
+	return func(c *Client) {
+		c.SetLogger(l)
+	}
+}
+
+// loggerRef lets an interface value live behind an atomic.Pointer.
+type loggerRef struct {
+	Logger
+}
+
+// SetLogger replaces the client's logger, taking effect for every message
+// logged from then on, including by the watch and reconnect goroutines. A nil
+// logger restores the default, which discards everything.
+func (c *Client) SetLogger(l Logger) {
+	if l == nil {
+		c.log.Store(nil)
+		return
+	}
+
+	c.log.Store(&loggerRef{Logger: l})
+}
+
+func (c *Client) logger() Logger {
+	var l Logger = noopLogger{}
+	if ref := c.log.Load(); ref != nil {
+		l = ref.Logger
+	}
+
+	if c.name == "" || l == (noopLogger{}) {
+		return l
+	}
+
+	return namedLogger{Logger: l, name: c.name}
+}
+
+// namedLogger tags every message with the name set by WithName.
+type namedLogger struct {
+	Logger
+	name string
+}
+
+func (l namedLogger) Debug(msg string, args ...any) {
+	l.Logger.Debug(msg, append([]any{"client", l.name}, args...)...)
+}
+
+func (l namedLogger) Info(msg string, args ...any) {
+	l.Logger.Info(msg, append([]any{"client", l.name}, args...)...)
+}
+
+func (l namedLogger) Warn(msg string, args ...any) {
+	l.Logger.Warn(msg, append([]any{"client", l.name}, args...)...)
+}
+
+func (l namedLogger) Error(msg string, args ...any) {
+	l.Logger.Error(msg, append([]any{"client", l.name}, args...)...)
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any) {}
+func (noopLogger) Info(string, ...any)  {}
+func (noopLogger) Warn(string, ...any)  {}
+func (noopLogger) Error(string, ...any) {}
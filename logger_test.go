@@ -0,0 +1,92 @@
+package dicedb
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// recordingLogger keeps the messages logged to it with their key-values.
+type recordingLogger struct {
+	mu   sync.Mutex
+	msgs []string
+	args [][]any
+}
+
+func (l *recordingLogger) record(msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.msgs = append(l.msgs, msg)
+	l.args = append(l.args, args)
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.record(msg, args) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.record(msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.record(msg, args) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.record(msg, args) }
+
+func (l *recordingLogger) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.msgs)
+}
+
+func TestClient_SetLogger(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithName("app"), WithSlowLog(1))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	first, second := &recordingLogger{}, &recordingLogger{}
+	client.SetLogger(first)
+	client.Fire(&wire.Command{Cmd: "PING"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				client.Fire(&wire.Command{Cmd: "PING"})
+			}
+		}()
+	}
+	client.SetLogger(second)
+	wg.Wait()
+	client.Fire(&wire.Command{Cmd: "PING"})
+
+	if first.count() == 0 || second.count() == 0 {
+		t.Fatalf("logged messages got = %d and %d, want both loggers used", first.count(), second.count())
+	}
+	if got := first.args[0]; len(got) < 2 || got[0] != "client" || got[1] != "app" {
+		t.Errorf("logged args got = %v, want them to start with client app", got)
+	}
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	server := newFakeServer(t, nil)
+
+	logger := &recordingLogger{}
+	client, err := NewClient(server.host(), server.port(), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+	if logger.count() == 0 {
+		t.Error("WithLogger() logger got no messages for a reconnect")
+	}
+
+	client.SetLogger(nil)
+	if _, ok := client.logger().(noopLogger); !ok {
+		t.Errorf("logger() after SetLogger(nil) got = %T, want the no-op default", client.logger())
+	}
+}
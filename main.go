@@ -1,28 +1,91 @@
 package dicedb
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log/slog"
-	"strings"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dicedb/dicedb-go/wire"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const maxResponseSize = 32 * 1024 * 1024 // 32 MB
 
+// Client is a connection to a DiceDB server. It is safe for concurrent use by
+// multiple goroutines.
 type Client struct {
-	id           string
-	mainMu       sync.Mutex
-	mainRetrier  *Retrier
-	mainWire     *ClientWire
-	watchRetrier *Retrier
-	watchWire    *ClientWire
-	watchCh      chan *wire.Result
-	host         string
-	port         int
+	id               string
+	name             string
+	mainMu           sync.Mutex
+	mainRetrier      *Retrier
+	mainWire         *ClientWire
+	watchRetrier     *Retrier
+	watchWire        *ClientWire
+	watchCh          chan *wire.Result
+	watchBufferSize  int
+	watchOverflow    OverflowPolicy
+	watchDropped     atomic.Int64
+	watchMu          sync.Mutex
+	watching         bool
+	watchErr         error
+	watchStop        context.CancelFunc
+	watchHolds       watchHolders
+	watchSession     context.Context
+	subs             map[uint64][]*subscription
+	host             string
+	port             int
+	nodes            []node
+	nodeIndex        atomic.Int32
+	slowLog          time.Duration
+	observer         Observer
+	tracing          bool
+	interceptors     []Interceptor
+	events           *eventLog
+	handshakeArgs    []string
+	auth             *credentials
+	commandBudget    time.Duration
+	commandTimeout   time.Duration
+	dialTimeout      time.Duration
+	connectBy        time.Time
+	keyPrefix        string
+	tlsConfig        *tls.Config
+	dialer           func(ctx context.Context, addr string) (net.Conn, error)
+	keepAlive        time.Duration
+	health           health
+	healthInterval   time.Duration
+	backoff          backoff
+	onReconnect      func(c *Client, conn string)
+	onReconnectError func(conn string, err error)
+	mainHooks        []func()
+	reconnectPolicy  ReconnectPolicy
+	backoffPolicy    BackoffPolicy
+	tokenizer        Tokenizer
+	importBatchSize  int
+	argFormatter     ArgFormatter
+	recorder         *recorder
+	stuckAfter       time.Duration
+	log              atomic.Pointer[loggerRef]
+	blocking         bool
+	state            atomic.Int32
+	stateMu          sync.Mutex
+	pauseMu          sync.Mutex
+	drainMu          sync.Mutex
+	draining         bool
+	inFlight         map[uint64]context.CancelFunc
+	nextInFlight     uint64
+	drained          chan struct{}
+	resumed          chan struct{}
+	stateChanges     []chan State
+	ready            chan struct{}
+	done             chan struct{}
+	readyOnce        sync.Once
+	closeOnce        sync.Once
 }
 
 type option func(*Client)
@@ -33,25 +96,96 @@ func WithID(id string) option {
 	}
 }
 
-func NewClient(host string, port int, opts ...option) (*Client, error) {
-	mainRetrier := NewRetrier(3, 5*time.Second)
-	clientWire, err := ExecuteWithResult(mainRetrier, []wire.ErrKind{wire.NotEstablished}, func() (*ClientWire, *wire.WireError) {
-		return NewClientWire(maxResponseSize, host, port)
-	}, noop)
+// WithName labels the client in its log output and events. Unlike the id, the
+// name is never sent to the server.
+func WithName(name string) option {
+	return func(c *Client) {
+		c.name = name
+	}
+}
 
-	if err != nil {
-		if err.Kind == wire.NotEstablished {
-			return nil, fmt.Errorf("could not connect to dicedb server after %d retries: %w", mainRetrier.maxRetries, err)
-		}
+// WithHandshakeArgs appends args to the HANDSHAKE command after the client id
+// and connection mode. They are sent again whenever a connection is restored.
+func WithHandshakeArgs(args ...string) option {
+	return func(c *Client) {
+		c.handshakeArgs = args
+	}
+}
 
-		return nil, fmt.Errorf("unexpected error when establishing server connection, report this to dicedb maintainers: %w", err)
+// WithDialTimeout sets how long dialing the server may take, for the first
+// connection as well as for reconnects. A zero or negative timeout keeps the
+// default of 5 seconds.
+func WithDialTimeout(d time.Duration) option {
+	return func(c *Client) {
+		c.dialTimeout = d
 	}
+}
 
+// WithTLSConfig makes the client connect over TLS using cfg, for the command
+// and watch connections alike. When cfg leaves ServerName empty, the host
+// given to NewClient is used.
+func WithTLSConfig(cfg *tls.Config) option {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithDialer makes the client open its command and watch connections with d
+// instead of a plain TCP dial, e.g. to go through a proxy. addr is the
+// host:port given to NewClient, and ctx expires after the dial timeout. When
+// WithTLSConfig is also set, TLS runs over the connection d returns.
+func WithDialer(d func(ctx context.Context, addr string) (net.Conn, error)) option {
+	return func(c *Client) {
+		c.dialer = d
+	}
+}
+
+// WithKeepAlive enables TCP keepalive with the given probe period on the
+// command and watch connections, so a connection silently dropped by a NAT or
+// firewall is noticed while idle rather than on the next command. It has no
+// effect on connections from a WithDialer dialer that are not TCP.
+func WithKeepAlive(d time.Duration) option {
+	return func(c *Client) {
+		c.keepAlive = d
+	}
+}
+
+// WithCommandTimeout bounds every write and every read of a command by d, so
+// a server that stops answering fails the command instead of blocking it. A
+// command that times out gets a Status_ERR result. Its reply may still arrive
+// later and would be mistaken for the next command's, so the connection is
+// closed and quietly replaced when the next command is fired.
+func WithCommandTimeout(d time.Duration) option {
+	return func(c *Client) {
+		c.commandTimeout = d
+	}
+}
+
+// WithTotalCommandBudget bounds the total time a single command may take,
+// including any reconnects and retries it goes through. Once the budget is
+// spent, the command fails with a Status_ERR result even if retries remain.
+func WithTotalCommandBudget(d time.Duration) option {
+	return func(c *Client) {
+		c.commandBudget = d
+	}
+}
+
+// WithSlowLog logs every command whose round trip takes at least threshold.
+func WithSlowLog(threshold time.Duration) option {
+	return func(c *Client) {
+		c.slowLog = threshold
+	}
+}
+
+func NewClient(host string, port int, opts ...option) (*Client, error) {
 	client := &Client{
-		mainRetrier: mainRetrier,
-		mainWire:    clientWire,
+		mainRetrier: NewRetrier(3, 5*time.Second),
 		host:        host,
 		port:        port,
+		events:      newEventLog(defaultEventBufferSize),
+		blocking:    true,
+		ready:       make(chan struct{}),
+		done:        make(chan struct{}),
 	}
 
 	for _, opt := range opts {
@@ -61,144 +195,756 @@ func NewClient(host string, port int, opts ...option) (*Client, error) {
 	if client.id == "" {
 		client.id = uuid.New().String()
 	}
+	client.events.name = client.name
+	if client.dialTimeout <= 0 {
+		client.dialTimeout = defaultDialTimeout
+	}
 
-	if resp := client.Fire(&wire.Command{
-		Cmd:  "HANDSHAKE",
-		Args: []string{client.id, "command"},
-	}); resp.Status == wire.Status_ERR {
-		return nil, fmt.Errorf("could not complete the handshake: %s", resp.Message)
+	if client.recorder != nil {
+		client.markReady()
+		return client, nil
+	}
+
+	if !client.blocking {
+		client.setState(StateReconnecting)
+		go client.connectInBackground()
+		client.startHealthCheck()
+		return client, nil
+	}
+
+	clientWire, err := client.connect()
+	if err != nil {
+		return nil, err
 	}
 
+	client.mainWire = clientWire
+	client.markReady()
+	client.startHealthCheck()
+
 	return client, nil
 }
 
-func (c *Client) fire(cmd *wire.Command, clientWire *ClientWire) *wire.Result {
-	c.mainMu.Lock()
-	defer c.mainMu.Unlock()
-
-	err := ExecuteVoid(c.mainRetrier, []wire.ErrKind{wire.Terminated}, func() *wire.WireError {
-		return clientWire.Send(cmd)
-	}, c.restoreMainWire)
+// connect dials the command connection and completes its handshake.
+func (c *Client) connect() (*ClientWire, error) {
+	// Only dial failures are retried; a server that refused the handshake
+	// would refuse it again.
+	var handshakeErr error
+	clientWire, err := ExecuteWithResult(c.mainRetrier, []wire.ErrKind{wire.NotEstablished}, func() (*ClientWire, *wire.WireError) {
+		clientWire, dialErr, hsErr := c.open(connCommand, c.dialTimeout, c.connectBy)
+		handshakeErr = hsErr
+		return clientWire, dialErr
+	}, noop)
 
 	if err != nil {
-		var message string
-
-		switch err.Kind {
-		case wire.Terminated:
-			message = fmt.Sprintf("failied to send command, connection terminated: %s", err.Cause)
-		case wire.CorruptMessage:
-			message = fmt.Sprintf("failied to send command, corrupt message: %s", err.Cause)
-		default:
-			message = fmt.Sprintf("failed to send command: unrecognized error, this should be reported to DiceDB maintainers: %s", err.Cause)
+		c.events.record(EventConnectFailed, connCommand, err)
+		if err.Kind == wire.NotEstablished {
+			return nil, fmt.Errorf("could not connect to dicedb server after %d retries: %w", c.mainRetrier.maxRetries, err)
 		}
-
-		return &wire.Result{
-			Status:  wire.Status_ERR,
-			Message: message,
+		if err.Kind == wire.Timeout && !c.connectBy.IsZero() {
+			return nil, fmt.Errorf("could not connect to dicedb server in time: %w", err)
 		}
+
+		return nil, fmt.Errorf("unexpected error when establishing server connection, report this to dicedb maintainers: %w", err)
+	}
+
+	if handshakeErr != nil {
+		c.events.record(EventConnectFailed, connCommand, handshakeErr)
+		return nil, handshakeErr
+	}
+
+	if !c.connectBy.IsZero() {
+		_ = clientWire.SetDeadline(time.Time{})
+	}
+	c.events.record(EventConnected, connCommand, nil)
+	return clientWire, nil
+}
+
+func (c *Client) handshake(clientWire *ClientWire, mode string) error {
+	cmd := &wire.Command{
+		Cmd:  "HANDSHAKE",
+		Args: append([]string{c.id, mode}, c.handshakeArgs...),
+	}
+
+	if err := clientWire.Send(cmd); err != nil {
+		return fmt.Errorf("could not complete the handshake: failed to send command: %w", err)
 	}
 
 	resp, err := clientWire.Receive()
 	if err != nil {
-		return &wire.Result{
-			Status:  wire.Status_ERR,
-			Message: fmt.Sprintf("failed to receive response: %s", err.Cause),
+		return fmt.Errorf("could not complete the handshake: failed to receive response: %w", err)
+	}
+
+	if resp.Status == wire.Status_ERR {
+		return fmt.Errorf("could not complete the handshake: %s", resp.Message)
+	}
+
+	return c.authenticate(clientWire)
+}
+
+// fire sends cmd and returns its result, along with the *ClientError it was
+// made from when the command failed on the client's side.
+func (c *Client) fire(ctx context.Context, cmd *wire.Command) (*wire.Result, *ClientError) {
+	if err := c.waitResumed(ctx); err != nil {
+		failure := aborted(err)
+		return failure.result(), failure
+	}
+
+	ctx, leave, ok := c.enter(ctx)
+	if !ok {
+		failure := shuttingDown()
+		return failure.result(), failure
+	}
+	defer leave()
+
+	if c.stuckAfter > 0 {
+		defer c.watchdog(cmd).Stop()
+	}
+
+	var span trace.Span
+	if c.tracing {
+		ctx, span = c.startSpan(ctx, cmd)
+	}
+
+	start := time.Now()
+	resp, failure := c.roundTrip(ctx, cmd)
+	c.observe(cmd, time.Since(start), resp)
+	c.health.track(resp)
+
+	if span != nil {
+		endSpan(span, resp)
+	}
+
+	return resp, failure
+}
+
+func (c *Client) observe(cmd *wire.Command, elapsed time.Duration, resp *wire.Result) {
+	if c.observer != nil {
+		c.observer.ObserveCommand(cmd.Cmd, elapsed, resp.Status)
+	}
+	if c.slowLog > 0 && elapsed >= c.slowLog {
+		c.logger().Warn("slow command", "cmd", cmd.Cmd, "duration", elapsed, "status", resp.Status)
+	}
+}
+
+func (c *Client) roundTrip(ctx context.Context, cmd *wire.Command) (*wire.Result, *ClientError) {
+	if c.recorder != nil {
+		return c.recorder.record(cmd), nil
+	}
+
+	c.mainMu.Lock()
+	defer c.unlockMain()
+
+	var failure *ClientError
+	switch {
+	case c.State() == StateClosed:
+		failure = clientClosed()
+	case c.mainWire == nil:
+		failure = notConnected()
+	case c.backoff.exhausted():
+		failure = reconnectsExhausted()
+	case ctx.Err() != nil:
+		failure = aborted(ctx.Err())
+	}
+	if failure != nil {
+		return failure.result(), failure
+	}
+
+	var budget time.Time
+	if c.commandBudget > 0 {
+		budget = time.Now().Add(c.commandBudget)
+	}
+
+	deadline := budget
+	if d, ok := ctx.Deadline(); ok {
+		deadline = earliest(deadline, d)
+	}
+	if !deadline.IsZero() || c.commandTimeout > 0 {
+		_ = c.mainWire.SetDeadline(deadline)
+		defer c.clearDeadline()
+	}
+
+	interrupt := c.interruptOn(ctx)
+	defer interrupt.release()
+
+	err := ExecuteVoid(c.mainRetrier, []wire.ErrKind{wire.Terminated}, func() *wire.WireError {
+		interrupt.arm(deadline, c.commandTimeout)
+		return c.mainWire.Send(cmd)
+	}, func() *wire.WireError {
+		// Reconnecting for a caller that has given up would be wasted work.
+		if err := ctx.Err(); err != nil {
+			return &wire.WireError{Kind: wire.Timeout, Cause: err}
+		}
+		if err := c.restoreMainWireBy(deadline); err != nil {
+			return err
+		}
+		interrupt.follow(c.mainWire)
+		return nil
+	})
+
+	if err != nil {
+		failure := c.commandFailure(ctx, budget, err, sendFailure)
+		return failure.result(), failure
+	}
+
+	interrupt.arm(deadline, c.commandTimeout)
+	resp, err := c.mainWire.Receive()
+	if err != nil {
+		failure := c.commandFailure(ctx, budget, err, receiveFailure)
+		return failure.result(), failure
+	}
+
+	return resp, nil
+}
+
+// interrupter expires the command connection's deadline once ctx is done, so a
+// blocked write or read returns at once. Callers must hold mainMu from
+// interruptOn until release.
+type interrupter struct {
+	ctx  context.Context
+	stop func() bool
+
+	mu sync.Mutex
+	// clientWire follows the connection through reconnects made along the
+	// way, and finished keeps a late cancellation from expiring it for the
+	// next command.
+	clientWire *ClientWire
+	finished   bool
+}
+
+func (c *Client) interruptOn(ctx context.Context) *interrupter {
+	i := &interrupter{ctx: ctx, clientWire: c.mainWire}
+	i.stop = context.AfterFunc(ctx, func() {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		if !i.finished {
+			_ = i.clientWire.SetDeadline(time.Now())
 		}
+	})
+	return i
+}
+
+// follow moves the interrupter to clientWire after a reconnect, expiring its
+// deadline at once if ctx was cancelled while the old one was being replaced.
+func (i *interrupter) follow(clientWire *ClientWire) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.clientWire = clientWire
+	if i.ctx.Err() != nil {
+		_ = clientWire.SetDeadline(time.Now())
 	}
+}
 
-	return resp
+// arm gives the next write or read its own timeout, bounded by deadline,
+// unless ctx has already expired it. A zero timeout leaves the deadline as is.
+func (i *interrupter) arm(deadline time.Time, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.ctx.Err() == nil {
+		_ = i.clientWire.SetDeadline(earliest(deadline, time.Now().Add(timeout)))
+	}
 }
 
+func (i *interrupter) release() {
+	i.mu.Lock()
+	i.finished = true
+	clientWire := i.clientWire
+	i.mu.Unlock()
+	if !i.stop() {
+		_ = clientWire.SetDeadline(time.Time{})
+	}
+}
+
+// earliest returns the earlier of two deadlines, where zero means none.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// commandFailure reports err from the reason the command ran out of time, if
+// it did, falling back to describe otherwise.
+func (c *Client) commandFailure(ctx context.Context, budget time.Time, err *wire.WireError, describe func(*wire.WireError) *ClientError) *ClientError {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return aborted(ctxErr)
+	}
+	// The socket deadline can expire a moment before ctx notices.
+	if d, ok := ctx.Deadline(); ok && deadlinePassed(d) {
+		return aborted(context.DeadlineExceeded)
+	}
+	if deadlinePassed(budget) {
+		return c.budgetFailure(err)
+	}
+	if err.Kind == wire.Timeout && c.commandTimeout > 0 {
+		return clientFailure(ErrTimeout, fmt.Sprintf("command timed out after %s: %s", c.commandTimeout, err.Cause), err.Cause)
+	}
+	return describe(err)
+}
+
+func aborted(err error) *ClientError {
+	var kind error
+	if errors.Is(err, context.DeadlineExceeded) {
+		kind = ErrTimeout
+	}
+	return clientFailure(kind, fmt.Sprintf("command aborted: %s", err), err)
+}
+
+func (c *Client) clearDeadline() {
+	if c.mainWire != nil {
+		_ = c.mainWire.SetDeadline(time.Time{})
+	}
+}
+
+func deadlinePassed(deadline time.Time) bool {
+	return !deadline.IsZero() && !time.Now().Before(deadline)
+}
+
+func (c *Client) budgetFailure(err *wire.WireError) *ClientError {
+	return clientFailure(ErrTimeout, fmt.Sprintf("command budget of %s exceeded: %s", c.commandBudget, err.Cause), err.Cause)
+}
+
+func sendFailure(err *wire.WireError) *ClientError {
+	var message string
+	kind := ErrConnClosed
+
+	switch err.Kind {
+	case wire.Terminated:
+		message = fmt.Sprintf("failied to send command, connection terminated: %s", err.Cause)
+	case wire.CorruptMessage:
+		// The command could not be encoded, so nothing was written.
+		message = fmt.Sprintf("failied to send command, corrupt message: %s", err.Cause)
+		kind = ErrInvalidCommand
+	case wire.Timeout:
+		message = fmt.Sprintf("failed to send command, timed out: %s", err.Cause)
+		kind = ErrTimeout
+	default:
+		message = fmt.Sprintf("failed to send command: unrecognized error, this should be reported to DiceDB maintainers: %s", err.Cause)
+	}
+
+	return clientFailure(kind, message, err.Cause)
+}
+
+func receiveFailure(err *wire.WireError) *ClientError {
+	message := fmt.Sprintf("failed to receive response: %s", err.Cause)
+	kind := ErrConnClosed
+	if err.Kind == wire.Timeout {
+		message = fmt.Sprintf("failed to receive response, timed out: %s", err.Cause)
+		kind = ErrTimeout
+	}
+
+	return clientFailure(kind, message, err.Cause)
+}
+
+// Fire sends cmd on the command connection and returns the server's reply. It
+// is safe for concurrent use: each command holds the connection from its write
+// until its reply is read, so replies always reach the caller that sent them.
 func (c *Client) Fire(cmd *wire.Command) *wire.Result {
-	return c.fire(cmd, c.mainWire)
+	return c.FireContext(context.Background(), cmd)
 }
 
-func (c *Client) FireString(cmdStr string) *wire.Result {
-	cmdStr = strings.TrimSpace(cmdStr)
-	tokens := strings.Split(cmdStr, " ")
+// FireContext is Fire bounded by ctx. Once ctx is done, the command stops
+// waiting, no reconnect is attempted on its behalf, and the result has
+// Status_ERR with a message naming ctx's error. A command interrupted midway
+// leaves the connection out of step with the server, so it is replaced on the
+// next command.
+func (c *Client) FireContext(ctx context.Context, cmd *wire.Command) *wire.Result {
+	return c.intercept(ctx, cmd, nil)
+}
 
-	var args []string
-	var cmd = tokens[0]
-	if len(tokens) > 1 {
-		args = tokens[1:]
+// FireAsync fires cmd on a goroutine of its own and returns a channel that
+// receives its result and is then closed. The command still takes its turn on
+// the connection like any other, so several FireAsync calls are sent one after
+// another rather than interleaved; what they save is the caller waiting on
+// each. The channel is buffered, so the goroutine finishes even if the result
+// is never read.
+func (c *Client) FireAsync(cmd *wire.Command) <-chan *wire.Result {
+	ch := make(chan *wire.Result, 1)
+	go func() {
+		defer close(ch)
+		ch <- c.Fire(cmd)
+	}()
+
+	return ch
+}
+
+// WatchCh opens the watch connection and returns the channel receiving the
+// updates pushed on it that no subscription claims. Transient disconnects are
+// retried; once the connection is terminated for good the channel is closed,
+// so a range over it ends, and WatchErr reports why. The channel buffers
+// updates; see WithWatchOverflow for what happens once it is full.
+func (c *Client) WatchCh() (<-chan *wire.Result, error) {
+	return c.WatchChContext(context.Background())
+}
+
+// WatchChContext is WatchCh with the watch connection held open only until ctx
+// is done. The watch connection is shared, so it stays open while anything
+// else still needs it: another WatchChContext whose ctx is live, a WatchCh
+// call, or an OnWatch or WatchCommand subscription, the last two holding it
+// until Close. Once the last holder is gone the connection is closed, any
+// reconnect in progress is abandoned, and the channel is closed; a later call
+// starts a fresh connection.
+func (c *Client) WatchChContext(ctx context.Context) (<-chan *wire.Result, error) {
+	c.watchMu.Lock()
+	if c.watchCh == nil {
+		c.watchCh = c.newWatchChan()
 	}
+	ch := c.watchCh
+	c.watchMu.Unlock()
+
+	if err := c.startWatch(ctx); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
 
-	return c.Fire(&wire.Command{
-		Cmd:  cmd,
-		Args: args,
+// watchHolders counts the callers holding the watch connection open, keeping
+// the stop function of the context.AfterFunc registered for each one whose
+// ctx can end.
+type watchHolders struct {
+	n     int
+	next  int
+	stops map[int]func() bool
+}
+
+// holdWatch counts ctx as holding the current watch session open until it is
+// done, stopping the session when it is the last holder to go. Callers must
+// hold watchMu.
+func (c *Client) holdWatch(ctx context.Context, session context.Context) {
+	c.watchHolds.n++
+	if ctx.Done() == nil {
+		return
+	}
+
+	id := c.watchHolds.next
+	c.watchHolds.next++
+	c.watchHolds.stops[id] = context.AfterFunc(ctx, func() {
+		c.watchMu.Lock()
+		defer c.watchMu.Unlock()
+
+		// The session may have ended with ctx already released.
+		if session.Err() != nil {
+			return
+		}
+		delete(c.watchHolds.stops, id)
+		c.watchHolds.n--
+		if c.watchHolds.n == 0 {
+			c.watchStop()
+		}
 	})
 }
 
-func (c *Client) WatchCh() (<-chan *wire.Result, error) {
-	var err *wire.WireError
-	if c.watchCh != nil {
-		return c.watchCh, nil
+// releaseWatch drops the holders of a watch session that has ended. Callers
+// must hold watchMu.
+func (c *Client) releaseWatch() {
+	for _, stop := range c.watchHolds.stops {
+		stop()
+	}
+	c.watchHolds = watchHolders{}
+}
+
+// startWatch opens the watch connection unless it is already open, and holds
+// it open until ctx is done either way.
+func (c *Client) startWatch(ctx context.Context) error {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	if c.watching {
+		c.holdWatch(ctx, c.watchSession)
+		return nil
+	}
+
+	if c.State() == StateClosed {
+		return errClosed
+	}
+
+	if c.recorder != nil {
+		return errDryRun
 	}
 
-	c.watchCh = make(chan *wire.Result)
 	c.watchRetrier = NewRetrier(5, 5*time.Second)
-	c.watchWire, err = NewClientWire(maxResponseSize, c.host, c.port)
+	watchWire, err, handshakeErr := c.open(connWatch, c.dialTimeout, time.Time{})
 	if err != nil {
-		return nil, fmt.Errorf("Failed to establish watch connection with server: %w", err)
+		c.events.record(EventConnectFailed, connWatch, err)
+		return fmt.Errorf("Failed to establish watch connection with server: %w", err)
 	}
-
-	if resp := c.fire(&wire.Command{
-		Cmd:  "HANDSHAKE",
-		Args: []string{c.id, "watch"},
-	}, c.watchWire); resp.Status == wire.Status_ERR {
-		return nil, fmt.Errorf("could not complete the handshake: %s", resp.Message)
+	if handshakeErr != nil {
+		c.events.record(EventConnectFailed, connWatch, handshakeErr)
+		return handshakeErr
 	}
 
-	go c.watch()
+	c.watchWire = watchWire
+	c.events.record(EventConnected, connWatch, nil)
+	c.watching = true
+	c.watchErr = nil
+
+	session, stop := context.WithCancel(context.Background())
+	c.watchSession = session
+	c.watchStop = stop
+	c.watchHolds = watchHolders{stops: map[int]func() bool{}}
+	c.holdWatch(ctx, session)
+	// Closing the connection interrupts a pending read.
+	context.AfterFunc(session, func() {
+		if w := c.currentWatchWire(); w != nil {
+			w.Close()
+		}
+	})
+	go c.watch(session)
 
-	return c.watchCh, nil
+	return nil
 }
 
-func (c *Client) watch() {
+// WatchErr returns the error that terminated the watch connection, or nil
+// while it is open or before it was ever opened. It is the way to tell why the
+// channel from WatchCh was closed.
+func (c *Client) WatchErr() error {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	return c.watchErr
+}
+
+func (c *Client) watch(session context.Context) {
 	for {
-		resp, err := ExecuteWithResult(c.watchRetrier, []wire.ErrKind{wire.Terminated}, c.watchWire.Receive, c.restoreWatchWire)
+		// The watch connection only ever reads, so an EOF from the server
+		// closing it is as much a disconnect as a terminated connection.
+		resp, err := ExecuteWithResult(c.watchRetrier, []wire.ErrKind{wire.Terminated, wire.Empty}, func() (*wire.Result, *wire.WireError) {
+			return c.currentWatchWire().Receive()
+		}, func() *wire.WireError {
+			if err := session.Err(); err != nil {
+				return &wire.WireError{Kind: wire.Terminated, Cause: err}
+			}
+			hook, err := c.restoreWatchWire(session)
+			if hook != nil {
+				defer hook()
+			}
+			if err != nil {
+				return err
+			}
+
+			c.resubscribe()
+			return nil
+		})
 
 		if err != nil {
-			slog.Error("watch connection has been terminated due to an error", "err", err)
-			close(c.watchCh)
+			var subErr error
+			if session.Err() != nil {
+				c.logger().Info("watch connection has been stopped")
+			} else {
+				subErr = err
+				c.logger().Error("watch connection has been terminated due to an error", "err", err)
+			}
+			c.events.record(EventDisconnected, connWatch, err)
+			c.watchMu.Lock()
+			c.watching = false
+			c.watchErr = err
+			c.watchStop()
+			c.releaseWatch()
+			if c.watchCh != nil {
+				close(c.watchCh)
+				c.watchCh = nil
+			}
 			c.watchWire.Close()
+			c.watchMu.Unlock()
+			c.closeSubscriptions(subErr)
 			break
 		}
 
-		c.watchCh <- resp
+		c.dispatch(resp)
 	}
 }
 
-func (c *Client) Close() {
-	c.mainWire.Close()
-	if c.watchCh != nil {
-		c.watchWire.Close()
+func (c *Client) currentWatchWire() *ClientWire {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	return c.watchWire
+}
+
+// Close closes the command and watch connections. The watch goroutine then
+// stops, closing the channel from WatchCh and every subscription, so consumers
+// ranging over them return. Commands fired afterwards fail with Status_ERR
+// without touching the network. Close returns the errors from closing the
+// connections; calling it again does nothing and returns nil.
+func (c *Client) Close() error {
+	var err error
+	c.closeOnce.Do(func() { err = c.close() })
+	return err
+}
+
+func (c *Client) close() error {
+	close(c.done)
+	c.setState(StateClosed)
+
+	var errs []error
+	c.mainMu.Lock()
+	if c.mainWire != nil {
+		if err := c.mainWire.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("could not close command connection: %w", err))
+		}
+	}
+	c.mainMu.Unlock()
+	c.events.record(EventClosed, connCommand, nil)
+
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	if !c.watching && c.watchCh != nil {
+		// No watch goroutine is left to close the channel.
 		close(c.watchCh)
+		c.watchCh = nil
+	}
+	if c.watchStop != nil {
+		c.watchStop()
+	}
+	if c.watchWire != nil {
+		if err := c.watchWire.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("could not close watch connection: %w", err))
+		}
+		c.events.record(EventClosed, connWatch, nil)
 	}
+
+	return errors.Join(errs...)
 }
 
+// restoreMainWire replaces the command connection. Callers must hold mainMu.
 func (c *Client) restoreMainWire() *wire.WireError {
-	return c.restoreWire(c.mainWire)
+	return c.restoreMainWireBy(time.Time{})
 }
 
-func (c *Client) restoreWatchWire() *wire.WireError {
-	return c.restoreWire(c.watchWire)
+// restoreMainWireBy is restoreMainWire bounded by deadline, which stays set on
+// the new connection. A zero deadline leaves it unbounded.
+func (c *Client) restoreMainWireBy(deadline time.Time) *wire.WireError {
+	c.setState(StateReconnecting)
+	clientWire, hook, err := c.restoreWire(connCommand, deadline)
+	if hook != nil {
+		c.mainHooks = append(c.mainHooks, hook)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.mainWire != nil {
+		c.mainWire.Close()
+	}
+	c.mainWire = clientWire
+	c.markReady()
+	return nil
 }
 
-func (c *Client) restoreWire(dst *ClientWire) *wire.WireError { // nolint:staticcheck
-	slog.Warn("trying to restore connection with server...")
-	var err *wire.WireError
+// Reconnect replaces the command connection with a freshly dialed one, even
+// when the current one still works, and repeats the handshake with the same
+// client id. Commands fired meanwhile wait for it to finish.
+func (c *Client) Reconnect() error {
+	c.mainMu.Lock()
+	defer c.unlockMain()
 
-	dst, err = NewClientWire(maxResponseSize, c.host, c.port) // nolint:ineffassign,staticcheck
-	if err != nil {
-		slog.Warn("failed to restore connection with server", "error", err)
-		return err
+	if c.State() == StateClosed {
+		return errClosed
+	}
+
+	if c.recorder != nil {
+		return nil
+	}
+
+	// An explicit reconnect is allowed after the reconnect policy gave up.
+	if c.backoff.exhausted() {
+		c.backoff.reset()
+	}
+
+	if err := c.restoreMainWire(); err != nil {
+		return fmt.Errorf("could not reconnect: %w", err)
 	}
 
-	slog.Info("connection restored successfully")
 	return nil
 }
 
+// restoreWatchWire replaces the watch connection. It returns the reconnect
+// hook, if any, for the watch goroutine to run once it has resubscribed.
+func (c *Client) restoreWatchWire(session context.Context) (func(), *wire.WireError) {
+	clientWire, hook, err := c.restoreWire(connWatch, time.Time{})
+	if err != nil {
+		return hook, err
+	}
+
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	// The session may have been stopped while dialing. Checking under watchMu
+	// guarantees a stop after this point finds and closes the new wire.
+	if err := session.Err(); err != nil {
+		clientWire.Close()
+		return hook, &wire.WireError{Kind: wire.Terminated, Cause: err}
+	}
+
+	c.watchWire.Close()
+	c.watchWire = clientWire
+	return hook, nil
+}
+
+// restoreWire dials a fresh connection and repeats the handshake on it with
+// the client's original id and handshake args, giving up at deadline unless it
+// is zero. It returns the reconnect hook for the outcome, if one is set, for
+// the caller to run once it holds no connection lock.
+func (c *Client) restoreWire(mode string, deadline time.Time) (*ClientWire, func(), *wire.WireError) {
+	clientWire, err := c.redial(mode, deadline)
+	if err != nil {
+		return nil, c.reconnectHook(mode, err), err
+	}
+
+	return clientWire, c.reconnectHook(mode, nil), nil
+}
+
+func (c *Client) redial(mode string, deadline time.Time) (*ClientWire, *wire.WireError) {
+	if c.backoff.exhausted() {
+		err := &wire.WireError{Kind: wire.NotEstablished, Cause: ErrMaxReconnect}
+		c.events.record(EventReconnectFailed, mode, err)
+		return nil, err
+	}
+
+	if err := c.backoff.wait(deadline, c.done); err != nil {
+		c.events.record(EventReconnectFailed, mode, err)
+		return nil, err
+	}
+
+	c.logger().Warn("trying to restore connection with server...", "conn", mode)
+	c.events.record(EventReconnecting, mode, nil)
+
+	timeout := c.dialTimeout
+	if !deadline.IsZero() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			err := &wire.WireError{Kind: wire.Timeout, Cause: errors.New("no time left to reconnect")}
+			c.events.record(EventReconnectFailed, mode, err)
+			return nil, err
+		}
+		timeout = min(timeout, remaining)
+	}
+
+	clientWire, err, handshakeErr := c.open(mode, timeout, deadline)
+	if err != nil {
+		c.backoff.failed(err, c.policy())
+		c.logger().Warn("failed to restore connection with server", "conn", mode, "error", err)
+		c.events.record(EventReconnectFailed, mode, err)
+		return nil, err
+	}
+
+	if err := handshakeErr; err != nil {
+		c.backoff.failed(err, c.policy())
+		c.logger().Warn("failed to restore connection with server", "conn", mode, "error", err)
+		c.events.record(EventReconnectFailed, mode, err)
+		return nil, &wire.WireError{Kind: wire.NotEstablished, Cause: err}
+	}
+
+	c.backoff.reset()
+	c.logger().Info("connection restored successfully", "conn", mode)
+	c.events.record(EventReconnected, mode, nil)
+	c.health.reconnects.Add(1)
+	return clientWire, nil
+}
+
 func noop() *wire.WireError {
 	return nil
 }
@@ -0,0 +1,24 @@
+package dicedb
+
+import (
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// Observer receives the outcome of every command sent with Fire or its
+// variants: the command name, how long it took including any reconnect, and
+// the final status, which is Status_ERR for commands that failed on the
+// connection as well as those the server rejected. It is called on the firing
+// goroutine, so it should return quickly. Batches are not reported.
+type Observer interface {
+	ObserveCommand(cmd string, dur time.Duration, status wire.Status)
+}
+
+// WithObserver reports every command to obs, e.g. to feed latency histograms
+// and error counters.
+func WithObserver(obs Observer) option {
+	return func(c *Client) {
+		c.observer = obs
+	}
+}
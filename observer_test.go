@@ -0,0 +1,70 @@
+package dicedb
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+type observation struct {
+	cmd    string
+	dur    time.Duration
+	status wire.Status
+}
+
+type recordingObserver struct {
+	mu  sync.Mutex
+	got []observation
+}
+
+func (o *recordingObserver) ObserveCommand(cmd string, dur time.Duration, status wire.Status) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.got = append(o.got, observation{cmd: cmd, dur: dur, status: status})
+}
+
+func TestClient_WithObserver(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "BAD" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR unknown command"}
+		}
+		return nil
+	})
+
+	obs := &recordingObserver{}
+	client, err := NewClient(server.host(), server.port(), WithObserver(obs))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	client.Fire(&wire.Command{Cmd: "PING"})
+	client.Fire(&wire.Command{Cmd: "BAD"})
+
+	want := []observation{{cmd: "PING", status: wire.Status_OK}, {cmd: "BAD", status: wire.Status_ERR}}
+	if len(obs.got) != len(want) {
+		t.Fatalf("ObserveCommand() calls got = %v, want %v", obs.got, want)
+	}
+	for i, o := range obs.got {
+		if o.cmd != want[i].cmd || o.status != want[i].status || o.dur <= 0 {
+			t.Errorf("ObserveCommand() call %d got = %+v, want %s %s with a duration", i, o, want[i].cmd, want[i].status)
+		}
+	}
+}
+
+func TestClient_ObserveAllocations(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 1, WithDryRun())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	cmd := &wire.Command{Cmd: "PING"}
+	resp := &wire.Result{Status: wire.Status_OK}
+	if allocs := testing.AllocsPerRun(100, func() { client.observe(cmd, time.Millisecond, resp) }); allocs != 0 {
+		t.Errorf("observe() without an observer allocated %v times, want 0", allocs)
+	}
+}
@@ -0,0 +1,59 @@
+package dicedb
+
+import (
+	"context"
+)
+
+// Pause holds back commands fired from now on until Resume is called, without
+// touching the connections. Commands already in flight complete normally, and
+// a held command fired through FireContext or FireBatchContext fails once its
+// context is done. Pausing a paused client does nothing.
+func (c *Client) Pause() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.resumed == nil {
+		c.resumed = make(chan struct{})
+	}
+}
+
+// Resume releases the commands held since Pause. Resuming a client that is not
+// paused does nothing.
+func (c *Client) Resume() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.resumed != nil {
+		close(c.resumed)
+		c.resumed = nil
+	}
+}
+
+// Paused reports whether the client is holding back commands.
+func (c *Client) Paused() bool {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	return c.resumed != nil
+}
+
+// waitResumed blocks while the client is paused, returning early with an error
+// if ctx is done or the client is closed first.
+func (c *Client) waitResumed(ctx context.Context) error {
+	c.pauseMu.Lock()
+	resumed := c.resumed
+	c.pauseMu.Unlock()
+
+	if resumed == nil {
+		return nil
+	}
+
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return errClosed
+	}
+}
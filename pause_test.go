@@ -0,0 +1,90 @@
+package dicedb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_PauseResume(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	client.Pause()
+	if !client.Paused() {
+		t.Fatalf("Paused() got = false, want true")
+	}
+
+	done := make(chan *wire.Result, 1)
+	go func() { done <- client.Fire(&wire.Command{Cmd: "PING"}) }()
+
+	select {
+	case resp := <-done:
+		t.Fatalf("Fire() returned while paused: %v", resp)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	client.Resume()
+	select {
+	case resp := <-done:
+		if resp.Status != wire.Status_OK {
+			t.Errorf("Fire() after Resume() status = %s: %s", resp.Status, resp.Message)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Fire() did not return after Resume()")
+	}
+
+	if client.Paused() {
+		t.Errorf("Paused() after Resume() got = true, want false")
+	}
+}
+
+func TestClient_PauseContext(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	client.Pause()
+	defer client.Resume()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp := client.FireContext(ctx, &wire.Command{Cmd: "PING"})
+	if resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, context.DeadlineExceeded.Error()) {
+		t.Errorf("FireContext() while paused got = %v, want a deadline error", resp)
+	}
+
+	if _, err := client.FireBatchContext(ctx, []*wire.Command{{Cmd: "PING"}}); err == nil {
+		t.Errorf("FireBatchContext() while paused error = nil, want the context error")
+	}
+}
+
+func TestClient_PauseClose(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Pause()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		client.Close()
+	}()
+
+	resp := client.Fire(&wire.Command{Cmd: "PING"})
+	if resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, errClosed.Error()) {
+		t.Errorf("Fire() on a paused client that closes got = %v, want a closed error", resp)
+	}
+}
@@ -0,0 +1,197 @@
+package dicedb
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/google/uuid"
+)
+
+// Pool spreads commands over several clients, each with its own connection,
+// so concurrent callers do not queue behind one another. Every connection
+// completes its own HANDSHAKE under an id derived from the one set with
+// WithID, suffixed with its slot number.
+//
+// A client reconnects on its own when a command finds its connection broken.
+// When it is still not connected once the command is done, the pool discards
+// it instead of handing it out again, and dials a replacement the next time
+// the slot is needed.
+type Pool struct {
+	host string
+	port int
+	opts []option
+	base string // id of the clients when no WithID is given
+	idle chan *poolConn
+	size int // slots handed out so far
+
+	// With WithKeyAffinity each slot waits in a channel of its own instead
+	// of idle.
+	affinity func(cmd *wire.Command) string
+	slots    []chan *poolConn
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// poolConn is a pool slot; client is nil once discarded.
+type poolConn struct {
+	slot   int
+	client *Client
+}
+
+// PoolOption configures a Pool. Every client option is also a PoolOption,
+// applied to each of the pool's clients.
+type PoolOption interface {
+	applyPool(p *Pool)
+}
+
+func (o option) applyPool(p *Pool) {
+	p.opts = append(p.opts, o)
+}
+
+type poolOption func(*Pool)
+
+func (o poolOption) applyPool(p *Pool) {
+	o(p)
+}
+
+// WithKeyAffinity makes a Pool send each command on the connection picked by
+// hashing key(cmd), so commands with the same key always share a connection
+// and reach the server in the order they were fired. A command then waits for
+// its own connection even while others are idle. Without it the pool hands out
+// whichever connection is idle.
+func WithKeyAffinity(key func(cmd *wire.Command) string) PoolOption {
+	return poolOption(func(p *Pool) {
+		p.affinity = key
+	})
+}
+
+// NewPool dials size clients to host:port, each created with the client
+// options among opts.
+func NewPool(host string, port int, size int, opts ...PoolOption) (*Pool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("pool size must be positive, got %d", size)
+	}
+
+	p := &Pool{
+		host: host,
+		port: port,
+		base: uuid.New().String(),
+		idle: make(chan *poolConn, size),
+		done: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt.applyPool(p)
+	}
+	if p.affinity != nil {
+		p.slots = make([]chan *poolConn, size)
+		for i := range p.slots {
+			p.slots[i] = make(chan *poolConn, 1)
+		}
+	}
+
+	for i := range size {
+		client, err := p.dial(i)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.put(&poolConn{slot: i, client: client})
+		p.size++
+	}
+
+	return p, nil
+}
+
+func (p *Pool) dial(slot int) (*Client, error) {
+	return NewClient(p.host, p.port, append(slices.Clip(p.opts), withSlot(p.base, slot))...)
+}
+
+// withSlot suffixes the client's id with its slot number, using base as the
+// id when none was set.
+func withSlot(base string, slot int) option {
+	return func(c *Client) {
+		if c.id == "" {
+			c.id = base
+		}
+		c.id = fmt.Sprintf("%s-%d", c.id, slot)
+	}
+}
+
+// Fire sends cmd on an idle connection, waiting for one to free up when all
+// of them are busy, or on the one its key maps to under WithKeyAffinity.
+func (p *Pool) Fire(cmd *wire.Command) *wire.Result {
+	var conn *poolConn
+	select {
+	case conn = <-p.queue(cmd):
+	case <-p.done:
+		return poolClosed().result()
+	}
+	defer p.put(conn)
+
+	select {
+	case <-p.done:
+		return poolClosed().result()
+	default:
+	}
+
+	if conn.client == nil {
+		client, err := p.dial(conn.slot)
+		if err != nil {
+			return clientFailure(ErrConnClosed, err.Error(), err).result()
+		}
+		conn.client = client
+	}
+
+	resp := conn.client.Fire(cmd)
+	if conn.client.State() != StateConnected {
+		conn.client.Close()
+		conn.client = nil
+	}
+
+	return resp
+}
+
+// queue returns the channel cmd takes its connection from.
+func (p *Pool) queue(cmd *wire.Command) chan *poolConn {
+	if p.slots == nil {
+		return p.idle
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(p.affinity(cmd)))
+	return p.slots[h.Sum32()%uint32(len(p.slots))]
+}
+
+func (p *Pool) put(conn *poolConn) {
+	if p.slots != nil {
+		p.slots[conn.slot] <- conn
+		return
+	}
+	p.idle <- conn
+}
+
+// Close waits for the commands in flight to finish and closes every
+// connection. Fire fails once Close has been called.
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+		for slot := range p.size {
+			queue := p.idle
+			if p.slots != nil {
+				queue = p.slots[slot]
+			}
+			conn := <-queue
+			if conn.client != nil {
+				conn.client.Close()
+			}
+		}
+	})
+}
+
+func poolClosed() *ClientError {
+	return clientFailure(ErrConnClosed, "pool is closed", nil)
+}
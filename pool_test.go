@@ -0,0 +1,85 @@
+package dicedb
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestPool(t *testing.T) {
+	server := newFakeServer(t, nil)
+	pool, err := NewPool(server.host(), server.port(), 3, WithID("app"))
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+
+	var ids []string
+	for _, cmd := range handshakes(server) {
+		ids = append(ids, cmd.Args[0])
+	}
+	slices.Sort(ids)
+	if want := []string{"app-0", "app-1", "app-2"}; !slices.Equal(ids, want) {
+		t.Errorf("handshake ids got = %v, want %v", ids, want)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp := pool.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+				t.Errorf("Fire() got = %v %q, want %v", resp.Status, resp.Message, wire.Status_OK)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pool.Close()
+	pool.Close()
+	if resp := pool.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_ERR {
+		t.Errorf("Fire() after Close got = %v, want %v", resp.Status, wire.Status_ERR)
+	}
+}
+
+func TestNewPool_InvalidSize(t *testing.T) {
+	if _, err := NewPool("127.0.0.1", 1, 0); err == nil {
+		t.Errorf("NewPool() with size 0 error = nil, want an error")
+	}
+}
+
+func TestPool_KeyAffinity(t *testing.T) {
+	server := newFakeServer(t, nil)
+	byKey := func(cmd *wire.Command) string { return cmd.Args[0] }
+	pool, err := NewPool(server.host(), server.port(), 3, WithKeyAffinity(byKey))
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	get := func(key string) *wire.Command { return &wire.Command{Cmd: "GET", Args: []string{key}} }
+	if pool.queue(get("a")) != pool.queue(get("a")) {
+		t.Errorf("queue() for the same key got different connections")
+	}
+	used := map[chan *poolConn]bool{}
+	for i := range 30 {
+		used[pool.queue(get(strconv.Itoa(i)))] = true
+	}
+	if len(used) != 3 {
+		t.Errorf("queue() over 30 keys used %d connections, want 3", len(used))
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp := pool.Fire(get(strconv.Itoa(i % 2))); resp.Status != wire.Status_OK {
+				t.Errorf("Fire() got = %v %q, want %v", resp.Status, resp.Message, wire.Status_OK)
+			}
+		}()
+	}
+	wg.Wait()
+}
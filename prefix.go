@@ -0,0 +1,19 @@
+package dicedb
+
+// WithKeyPrefix prepends prefix to every key passed to the client's helpers:
+// Get, Set, Del, Incr, Expire, ExpireWithFlag, GetEx, GetExPersist, GetAuto,
+// SetIfExists, SetIfAbsent, MSetWithTTL, IncrWithExpiry, Rotate,
+// RotateWithBackup, OnWatch, WaitForKey, AcquireSemaphore and
+// ReleaseSemaphore. Commands built by the caller, whether
+// given to Fire, FireString, FireTokens, FireTyped, FireBatch, FireStream,
+// WatchCommand or Import, are sent untouched, so they can reach keys outside
+// the prefix.
+func WithKeyPrefix(prefix string) option {
+	return func(c *Client) {
+		c.keyPrefix = prefix
+	}
+}
+
+func (c *Client) key(key string) string {
+	return c.keyPrefix + key
+}
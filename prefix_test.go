@@ -0,0 +1,32 @@
+package dicedb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_KeyPrefix(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 1, WithDryRun(), WithKeyPrefix("tenant:"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, _ = client.SetIfAbsent("k", "v")
+	_ = client.MSetWithTTL([]SetEntry{{Key: "a", Value: "1"}})
+	_, _ = client.Expire("k", time.Second)
+	client.Fire(&wire.Command{Cmd: "GET", Args: []string{"raw"}})
+
+	want := []string{"tenant:k", "tenant:a", "tenant:k", "raw"}
+	got := client.RecordedCommands()
+	if len(got) != len(want) {
+		t.Fatalf("RecordedCommands() got %d commands, want %d", len(got), len(want))
+	}
+	for i, cmd := range got {
+		if cmd.Args[0] != want[i] {
+			t.Errorf("%s key got = %q, want %q", cmd.Cmd, cmd.Args[0], want[i])
+		}
+	}
+}
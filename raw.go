@@ -0,0 +1,43 @@
+package dicedb
+
+import (
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// FireRaw fires cmd with args given as bytes. The protocol carries arguments
+// as protobuf strings, which must be valid UTF-8, so each argument is sent
+// byte for byte when it is and rejected otherwise: FireRaw then returns a
+// Status_ERR result naming the argument without sending anything. Store
+// arbitrary binary data, such as images or serialized messages, by passing it
+// through EncodeBinary first and DecodeBinary after reading it back.
+func (c *Client) FireRaw(cmd string, args ...[]byte) *wire.Result {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		if !utf8.Valid(arg) {
+			return clientFailure(ErrInvalidCommand, fmt.Sprintf("could not fire command: argument %d is not valid UTF-8, encode it with EncodeBinary", i), nil).result()
+		}
+		strs[i] = string(arg)
+	}
+
+	return c.Fire(&wire.Command{Cmd: cmd, Args: strs})
+}
+
+// EncodeBinary encodes b as standard base64, which survives the protocol's
+// string arguments unchanged.
+func EncodeBinary(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// DecodeBinary reverses EncodeBinary.
+func DecodeBinary(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode binary value: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,60 @@
+package dicedb
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_FireRaw(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	blob := []byte{0x00, 0xff, 0xfe, 'a'}
+	if resp := client.FireRaw("SET", []byte("k"), blob); resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, "argument 1") {
+		t.Errorf("FireRaw() with invalid UTF-8 got = %v, want an error naming argument 1", resp)
+	}
+
+	encoded := EncodeBinary(blob)
+	if resp := client.FireRaw("SET", []byte("k"), []byte(encoded)); resp.Status != wire.Status_OK {
+		t.Fatalf("FireRaw() with an encoded value got = %v, want OK", resp)
+	}
+
+	cmds := server.commands()
+	last := cmds[len(cmds)-1]
+	if last.Cmd != "SET" || !slices.Equal(last.Args, []string{"k", encoded}) {
+		t.Errorf("FireRaw() sent %v, want SET k %s", last, encoded)
+	}
+	if got, err := DecodeBinary(last.Args[1]); err != nil || !bytes.Equal(got, blob) {
+		t.Errorf("DecodeBinary() got = %v, %v, want %v", got, err, blob)
+	}
+	if _, err := DecodeBinary("not base64!"); err == nil {
+		t.Error("DecodeBinary() of invalid input error = nil, want error")
+	}
+}
+
+func TestClient_InvalidUTF8KeepsConnection(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if resp := client.FireTokens("SET", "k", "\xff"); resp.Status != wire.Status_ERR {
+		t.Errorf("FireTokens() with invalid UTF-8 got = %v, want an error", resp)
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() after a rejected command got = %v, want OK", resp)
+	}
+	if got := len(handshakes(server)); got != 1 {
+		t.Errorf("got %d handshakes, want 1: the connection should have been kept", got)
+	}
+}
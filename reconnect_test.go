@@ -0,0 +1,390 @@
+package dicedb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// fireUntilOK fires cmd until it succeeds, giving the client a few commands to
+// notice a dropped connection and restore it.
+func fireUntilOK(t *testing.T, client *Client, cmd *wire.Command) *wire.Result {
+	t.Helper()
+
+	resp := retryFire(client, cmd)
+	if resp.Status != wire.Status_OK {
+		t.Fatalf("Fire(%s) did not recover after reconnect: %s", cmd.Cmd, resp.Message)
+	}
+	return resp
+}
+
+// retryFire fires cmd up to five times until it succeeds and returns the last
+// result. Unlike fireUntilOK it is safe to call off the test goroutine.
+func retryFire(client *Client, cmd *wire.Command) *wire.Result {
+	var resp *wire.Result
+	for i := 0; i < 5; i++ {
+		if resp = client.Fire(cmd); resp.Status == wire.Status_OK {
+			break
+		}
+	}
+	return resp
+}
+
+func handshakes(server *fakeServer) []*wire.Command {
+	var out []*wire.Command
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "HANDSHAKE" {
+			out = append(out, cmd)
+		}
+	}
+	return out
+}
+
+func TestClient_ReconnectReplaysHandshakeArgs(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithID("c1"), WithHandshakeArgs("v2", "compress"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	server.dropConns()
+	fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+
+	got := handshakes(server)
+	if len(got) != 2 {
+		t.Fatalf("got %d handshakes, want 2", len(got))
+	}
+	want := []string{"c1", "command", "v2", "compress"}
+	for _, cmd := range got {
+		if !slices.Equal(cmd.Args, want) {
+			t.Errorf("HANDSHAKE args = %v, want %v", cmd.Args, want)
+		}
+	}
+}
+
+func TestClient_ReconnectKeepsGeneratedID(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	id := client.id
+	server.dropConns()
+	fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+
+	if client.id != id {
+		t.Errorf("id after reconnect got = %s, want %s", client.id, id)
+	}
+	for _, cmd := range handshakes(server) {
+		if cmd.Args[0] != id {
+			t.Errorf("HANDSHAKE id got = %s, want %s", cmd.Args[0], id)
+		}
+	}
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithID("c1"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() after Reconnect() status = %s: %s", resp.Status, resp.Message)
+	}
+
+	got := handshakes(server)
+	if len(got) != 2 || got[1].Args[0] != "c1" {
+		t.Errorf("handshakes = %v, want two with id c1", got)
+	}
+
+	client.Close()
+	if err := client.Reconnect(); err == nil {
+		t.Errorf("Reconnect() after Close() error = nil, want error")
+	}
+}
+
+func TestClient_ConcurrentReconnect(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port(), WithID("c1"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.WatchCommand(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}})
+	if err != nil {
+		t.Fatalf("WatchCommand() error = %v", err)
+	}
+
+	// Both connections drop at once; each side has to recover on its own
+	// without waiting on the other.
+	server.dropConns()
+
+	fired := make(chan *wire.Result, 1)
+	go func() {
+		fired <- retryFire(client, &wire.Command{Cmd: "PING"})
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		server.push(&wire.Result{Status: wire.Status_OK, Fingerprint64: 42, Message: "update"})
+
+		select {
+		case res, ok := <-ch:
+			if !ok {
+				t.Fatal("watch channel closed instead of reconnecting")
+			}
+			if res.Message != "update" {
+				t.Fatalf("watch got = %v, want update", res)
+			}
+		case <-time.After(10 * time.Millisecond):
+			continue
+		case <-deadline:
+			t.Fatal("watch connection did not recover, possible deadlock")
+		}
+		break
+	}
+
+	select {
+	case resp := <-fired:
+		if resp.Status != wire.Status_OK {
+			t.Errorf("Fire(PING) did not recover after reconnect: %s", resp.Message)
+		}
+	case <-deadline:
+		t.Fatal("command connection did not recover, possible deadlock")
+	}
+}
+
+func TestClient_TotalCommandBudget(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET" {
+			time.Sleep(500 * time.Millisecond)
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithTotalCommandBudget(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	resp := client.Fire(&wire.Command{Cmd: "GET", Args: []string{"k"}})
+	if resp.Status != wire.Status_ERR {
+		t.Fatalf("Fire() status = %v, want %v", resp.Status, wire.Status_ERR)
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("Fire() took %s, want it bounded by the budget", elapsed)
+	}
+
+	// The timed out connection is restored on the next command.
+	fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+}
+
+func TestClient_FireContext(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET" {
+			time.Sleep(500 * time.Millisecond)
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	timeout := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 50*time.Millisecond)
+	}
+	cancelled := func() (context.Context, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		return ctx, cancel
+	}
+
+	tests := []struct {
+		name string
+		ctx  func() (context.Context, context.CancelFunc)
+		want string
+	}{
+		{name: "deadline", ctx: timeout, want: "command aborted: context deadline exceeded"},
+		{name: "cancel", ctx: cancelled, want: "command aborted: context canceled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			resp := client.FireContext(ctx, &wire.Command{Cmd: "GET", Args: []string{"k"}})
+			if resp.Status != wire.Status_ERR || resp.Message != tt.want {
+				t.Errorf("FireContext() got = %v %q, want %v %q", resp.Status, resp.Message, wire.Status_ERR, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+				t.Errorf("FireContext() took %s, want it to return on %s", elapsed, tt.name)
+			}
+
+			fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+		})
+	}
+}
+
+func TestClient_FireContextCancelAfterReconnect(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET" {
+			time.Sleep(2 * time.Second)
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	// A closed wire makes the GET reconnect before it is sent, so the read
+	// that ctx has to interrupt happens on the new connection.
+	client.mainMu.Lock()
+	client.mainWire.Close()
+	client.mainMu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	resp := client.FireContext(ctx, &wire.Command{Cmd: "GET", Args: []string{"k"}})
+	if want := "command aborted: context canceled"; resp.Status != wire.Status_ERR || resp.Message != want {
+		t.Errorf("FireContext() got = %v %q, want %v %q", resp.Status, resp.Message, wire.Status_ERR, want)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("FireContext() took %s, want it to return on cancel", elapsed)
+	}
+	if got := len(handshakes(server)); got != 2 {
+		t.Errorf("got %d handshakes, want the GET to have reconnected once", got)
+	}
+}
+
+func TestClient_CommandTimeout(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET" {
+			time.Sleep(300 * time.Millisecond)
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithCommandTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	resp := client.Fire(&wire.Command{Cmd: "GET", Args: []string{"k"}})
+	if resp.Status != wire.Status_ERR || !strings.HasPrefix(resp.Message, "command timed out after 50ms") {
+		t.Errorf("Fire() got = %v %q, want a command timeout", resp.Status, resp.Message)
+	}
+
+	// The next command gets a fresh connection instead of the late GET reply.
+	resp = fireUntilOK(t, client, &wire.Command{Cmd: "PING"})
+	if resp.Message != "OK" {
+		t.Errorf("Fire() got = %q, want the PING reply", resp.Message)
+	}
+}
+
+func TestClient_ConcurrentFire(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "ECHO" {
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_ECHORes{ECHORes: &wire.ECHORes{Message: cmd.Args[0]}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	const goroutines, commands = 32, 50
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < commands; i++ {
+				msg := fmt.Sprintf("%d-%d", g, i)
+				resp := client.Fire(&wire.Command{Cmd: "ECHO", Args: []string{msg}})
+				// Commands caught by the dropped connection may fail, but a
+				// reply must never belong to another command.
+				if resp.Status == wire.Status_OK && resp.GetECHORes().GetMessage() != msg {
+					t.Errorf("Fire(ECHO %s) got = %s, want %s", msg, resp.GetECHORes().GetMessage(), msg)
+				}
+			}
+		}()
+	}
+
+	// Dropping the connection midway makes the reconnect path take the lock
+	// while other commands wait on it.
+	time.Sleep(5 * time.Millisecond)
+	server.dropConns()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("concurrent Fire calls did not finish, possible deadlock")
+	}
+}
+
+func TestClient_FireAsync(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "ECHO" {
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_ECHORes{ECHORes: &wire.ECHORes{Message: cmd.Args[0]}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	futures := make([]<-chan *wire.Result, 20)
+	for i := range futures {
+		futures[i] = client.FireAsync(&wire.Command{Cmd: "ECHO", Args: []string{fmt.Sprint(i)}})
+	}
+
+	for i, future := range futures {
+		select {
+		case resp := <-future:
+			if got := resp.GetECHORes().GetMessage(); got != fmt.Sprint(i) {
+				t.Errorf("FireAsync(ECHO %d) got = %s, want %d", i, got, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("FireAsync(ECHO %d) got no result", i)
+		}
+		if _, ok := <-future; ok {
+			t.Errorf("FireAsync(ECHO %d) channel still open after the result", i)
+		}
+	}
+}
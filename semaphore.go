@@ -0,0 +1,98 @@
+package dicedb
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/google/uuid"
+)
+
+// AcquireSemaphore tries to take one of limit slots of the semaphore stored at
+// key. Slots go in the order of a counter the server increments for every
+// request, so the earliest requests get them whatever their clocks say. A
+// holder that has not released once ttl has passed is evicted. The returned
+// token is only valid when acquired is true and must be passed to
+// ReleaseSemaphore.
+//
+// The semaphore lives in three keys: key ranks holders by their counter value,
+// key:acquired scores them by the time they acquired for eviction, and
+// key:counter is the counter. It is not strictly fair: a request that reads the
+// counter but is slow to join key can rank ahead of one that joined after it
+// and already took a slot, so limit may briefly be exceeded by one per such
+// straggler.
+func (c *Client) AcquireSemaphore(key string, limit int64, ttl time.Duration) (token string, acquired bool, err error) {
+	if limit <= 0 {
+		return "", false, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	if ttl <= 0 {
+		return "", false, fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+
+	key = c.key(key)
+	if err := c.evictHolders(key, time.Now().Add(-ttl).UnixMilli()); err != nil {
+		return "", false, err
+	}
+
+	resp, err := c.do(&wire.Command{Cmd: "INCR", Args: []string{key + ":counter"}})
+	if err != nil {
+		return "", false, err
+	}
+	ticket := resp.GetINCRRes().GetValue()
+
+	token = uuid.New().String()
+	resp, err = c.do(&wire.Command{Cmd: "ZADD", Args: []string{key + ":acquired", c.formatInt(time.Now().UnixMilli()), token}})
+	if err != nil {
+		return "", false, err
+	}
+	resp, err = c.do(&wire.Command{Cmd: "ZADD", Args: []string{key, c.formatInt(ticket), token}})
+	if err != nil {
+		_ = c.releaseSemaphore(key, token)
+		return "", false, err
+	}
+
+	resp, err = c.do(&wire.Command{Cmd: "ZRANGE", Args: []string{key, "0", c.formatInt(limit - 1)}})
+	if err != nil {
+		_ = c.releaseSemaphore(key, token)
+		return "", false, err
+	}
+
+	if !slices.Contains(wire.Members(resp.GetZRANGERes().GetElements()), token) {
+		if err := c.releaseSemaphore(key, token); err != nil {
+			return "", false, err
+		}
+		return "", false, nil
+	}
+
+	return token, true, nil
+}
+
+// ReleaseSemaphore gives back the slot held by token.
+func (c *Client) ReleaseSemaphore(key, token string) error {
+	return c.releaseSemaphore(c.key(key), token)
+}
+
+func (c *Client) releaseSemaphore(key string, tokens ...string) error {
+	if _, err := c.do(&wire.Command{Cmd: "ZREM", Args: append([]string{key}, tokens...)}); err != nil {
+		return err
+	}
+	_, err := c.do(&wire.Command{Cmd: "ZREM", Args: append([]string{key + ":acquired"}, tokens...)})
+	return err
+}
+
+// evictHolders removes the holders that acquired at or before cutoff, in unix
+// milliseconds.
+func (c *Client) evictHolders(key string, cutoff int64) error {
+	resp, err := c.do(&wire.Command{Cmd: "ZRANGE", Args: []string{key + ":acquired", "0", c.formatInt(cutoff), "BYSCORE"}})
+	if err != nil {
+		return err
+	}
+
+	expired := wire.Members(resp.GetZRANGERes().GetElements())
+	if len(expired) == 0 {
+		return nil
+	}
+
+	return c.releaseSemaphore(key, expired...)
+}
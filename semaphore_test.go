@@ -0,0 +1,137 @@
+package dicedb
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// zsetHandler keeps in-memory sorted sets and counters, enough for the INCR,
+// ZADD, ZRANGE and ZREM calls the semaphore makes.
+func zsetHandler() func(cmd *wire.Command) *wire.Result {
+	var mu sync.Mutex
+	sets := map[string]map[string]int64{}
+	counters := map[string]int64{}
+
+	sorted := func(key string) []*wire.ZElement {
+		var out []*wire.ZElement
+		for m, s := range sets[key] {
+			out = append(out, &wire.ZElement{Member: m, Score: s})
+		}
+		slices.SortFunc(out, func(a, b *wire.ZElement) int {
+			if a.Score != b.Score {
+				return int(a.Score - b.Score)
+			}
+			return strings.Compare(a.Member, b.Member)
+		})
+		return out
+	}
+
+	return func(cmd *wire.Command) *wire.Result {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch cmd.Cmd {
+		case "INCR":
+			counters[cmd.Args[0]]++
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: counters[cmd.Args[0]]}}}
+		case "ZADD":
+			if sets[cmd.Args[0]] == nil {
+				sets[cmd.Args[0]] = map[string]int64{}
+			}
+			score, _ := strconv.ParseInt(cmd.Args[1], 10, 64)
+			sets[cmd.Args[0]][cmd.Args[2]] = score
+		case "ZREM":
+			for _, m := range cmd.Args[1:] {
+				delete(sets[cmd.Args[0]], m)
+			}
+		case "ZRANGE":
+			start, _ := strconv.ParseInt(cmd.Args[1], 10, 64)
+			stop, _ := strconv.ParseInt(cmd.Args[2], 10, 64)
+			var elements []*wire.ZElement
+			for i, e := range sorted(cmd.Args[0]) {
+				if len(cmd.Args) > 3 && e.Score >= start && e.Score <= stop {
+					elements = append(elements, e)
+				} else if len(cmd.Args) == 3 && int64(i) >= start && int64(i) <= stop {
+					elements = append(elements, e)
+				}
+			}
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_ZRANGERes{ZRANGERes: &wire.ZRANGERes{Elements: elements}}}
+		}
+		return nil
+	}
+}
+
+func TestClient_Semaphore(t *testing.T) {
+	server := newFakeServer(t, zsetHandler())
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	acquire := func(ttl time.Duration) (string, bool) {
+		t.Helper()
+		token, ok, err := client.AcquireSemaphore("sem", 2, ttl)
+		if err != nil {
+			t.Fatalf("AcquireSemaphore() error = %v", err)
+		}
+		return token, ok
+	}
+
+	first, ok := acquire(time.Minute)
+	if !ok {
+		t.Fatalf("AcquireSemaphore() first got = false, want true")
+	}
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := acquire(time.Minute); !ok {
+		t.Fatalf("AcquireSemaphore() second got = false, want true")
+	}
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := acquire(time.Minute); ok {
+		t.Errorf("AcquireSemaphore() over the limit got = true, want false")
+	}
+
+	if err := client.ReleaseSemaphore("sem", first); err != nil {
+		t.Fatalf("ReleaseSemaphore() error = %v", err)
+	}
+	if _, ok := acquire(time.Minute); !ok {
+		t.Errorf("AcquireSemaphore() after release got = false, want true")
+	}
+
+	// Both holders are older than a 1ms ttl by now, so they get evicted.
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := acquire(time.Millisecond); !ok {
+		t.Errorf("AcquireSemaphore() after expiry got = false, want true")
+	}
+}
+
+func TestClient_SemaphoreRanksByCounter(t *testing.T) {
+	server := newFakeServer(t, zsetHandler())
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for range 2 {
+		if _, ok, err := client.AcquireSemaphore("sem", 2, time.Minute); err != nil || !ok {
+			t.Fatalf("AcquireSemaphore() got = %v, %v, want true, nil", ok, err)
+		}
+	}
+
+	var scores []string
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "ZADD" && cmd.Args[0] == "sem" {
+			scores = append(scores, cmd.Args[1])
+		}
+	}
+	if want := []string{"1", "2"}; !slices.Equal(scores, want) {
+		t.Errorf("ZADD sem scores got = %v, want the counter values %v", scores, want)
+	}
+}
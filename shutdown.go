@@ -0,0 +1,88 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+)
+
+var errShuttingDown = errors.New("client is shutting down")
+
+// Shutdown closes the client gracefully. Commands fired from then on fail
+// with a Status_ERR result, while the ones already in flight, batches
+// included, are given until ctx is done to finish before the connections are
+// closed. If ctx ends first, the commands still running are cancelled as if
+// their own context had been, and Shutdown returns ctx's error along with any
+// from closing. A cancelled batch stops writing but still reads the replies
+// to the commands it has written, so Shutdown may outlast ctx by that long.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.drainMu.Lock()
+	c.draining = true
+	var drained chan struct{}
+	if len(c.inFlight) > 0 {
+		if c.drained == nil {
+			c.drained = make(chan struct{})
+		}
+		drained = c.drained
+	}
+	c.drainMu.Unlock()
+
+	var ctxErr error
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			c.cancelInFlight()
+		}
+	}
+
+	return errors.Join(ctxErr, c.Close())
+}
+
+// enter registers a command with Shutdown and returns the context it should
+// run under, which Shutdown cancels when it runs out of time. It reports false
+// once the client is draining. The returned leave must be called when the
+// command is done.
+func (c *Client) enter(ctx context.Context) (context.Context, func(), bool) {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+
+	if c.draining {
+		return nil, nil, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.nextInFlight++
+	id := c.nextInFlight
+	if c.inFlight == nil {
+		c.inFlight = make(map[uint64]context.CancelFunc)
+	}
+	c.inFlight[id] = cancel
+
+	return ctx, func() { c.leave(id) }, true
+}
+
+func (c *Client) leave(id uint64) {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+
+	c.inFlight[id]()
+	delete(c.inFlight, id)
+	if len(c.inFlight) == 0 && c.drained != nil {
+		close(c.drained)
+		c.drained = nil
+	}
+}
+
+func (c *Client) cancelInFlight() {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+
+	for _, cancel := range c.inFlight {
+		cancel()
+	}
+}
+
+func shuttingDown() *ClientError {
+	return clientFailure(ErrConnClosed, "could not fire command: "+errShuttingDown.Error(), errShuttingDown)
+}
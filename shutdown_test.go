@@ -0,0 +1,128 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// slowServer holds its reply to SLOW until release is closed.
+func slowServer(t *testing.T, release <-chan struct{}) *fakeServer {
+	return newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "SLOW" {
+			<-release
+			return &wire.Result{Status: wire.Status_OK, Message: "OK"}
+		}
+		return nil
+	})
+}
+
+// fireSlow fires SLOW in the background and waits for the server to get it.
+func fireSlow(t *testing.T, client *Client, server *fakeServer) <-chan *wire.Result {
+	t.Helper()
+
+	result := client.FireAsync(&wire.Command{Cmd: "SLOW"})
+	deadline := time.Now().Add(time.Second)
+	for {
+		for _, cmd := range server.commands() {
+			if cmd.Cmd == "SLOW" {
+				return result
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("SLOW never reached the server")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func waitDraining(t *testing.T, client *Client) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		client.drainMu.Lock()
+		draining := client.draining
+		client.drainMu.Unlock()
+		if draining {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Shutdown() did not start draining")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestClient_Shutdown(t *testing.T) {
+	release := make(chan struct{})
+	server := slowServer(t, release)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	slow := fireSlow(t, client, server)
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- client.Shutdown(context.Background()) }()
+	waitDraining(t, client)
+
+	resp := client.Fire(&wire.Command{Cmd: "PING"})
+	if resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, errShuttingDown.Error()) {
+		t.Errorf("Fire() while shutting down got = %v %q, want a shutting down error", resp.Status, resp.Message)
+	}
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown() returned %v with a command in flight", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	if resp := <-slow; resp.Status != wire.Status_OK {
+		t.Errorf("in-flight Fire() got = %v %q, want OK", resp.Status, resp.Message)
+	}
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown() did not return after the command finished")
+	}
+	if got := client.State(); got != StateClosed {
+		t.Errorf("State() after Shutdown got = %s, want closed", got)
+	}
+}
+
+func TestClient_ShutdownContextExpires(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := slowServer(t, release)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	slow := fireSlow(t, client, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	select {
+	case resp := <-slow:
+		if resp.Status != wire.Status_ERR {
+			t.Errorf("in-flight Fire() got = %v, want it cut off by the close", resp.Status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("in-flight Fire() still running after Shutdown")
+	}
+}
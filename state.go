@@ -0,0 +1,189 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+const (
+	connectRetryInterval = time.Second
+	readyPollInterval    = 250 * time.Millisecond
+	stateChangesBuffer   = 16
+)
+
+var errClosed = errors.New("client is closed")
+
+type State int32
+
+const (
+	StateConnected State = iota + 1
+	StateReconnecting
+	StateClosed
+)
+
+func (s State) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// WithConnectBlocking controls whether NewClient waits for the command
+// connection. With blocking set to false, NewClient returns straight away with
+// the client in StateReconnecting and keeps dialing in the background until it
+// connects or the client is closed. Commands fired before then are not queued:
+// they fail immediately with a Status_ERR result. Use WaitReady to wait for the
+// connection.
+func WithConnectBlocking(blocking bool) option {
+	return func(c *Client) {
+		c.blocking = blocking
+	}
+}
+
+func (c *Client) State() State {
+	return State(c.state.Load())
+}
+
+func (c *Client) setState(s State) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	// Closed is terminal; a reconnect racing with Close must not revive it.
+	old := c.State()
+	if old == StateClosed || old == s {
+		return
+	}
+	c.state.Store(int32(s))
+
+	for _, ch := range c.stateChanges {
+		select {
+		case ch <- s:
+		default:
+		}
+	}
+
+	if s == StateClosed {
+		for _, ch := range c.stateChanges {
+			close(ch)
+		}
+		c.stateChanges = nil
+	}
+}
+
+// StateChanges returns a channel receiving the client's new state on every
+// transition between StateConnected, StateReconnecting and StateClosed. The
+// channel is closed after StateClosed is sent, so ranging over it ends with
+// the client. It buffers a few changes; a consumer that falls further behind
+// misses the ones in between and can call State for the current one.
+func (c *Client) StateChanges() <-chan State {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	ch := make(chan State, stateChangesBuffer)
+	if c.State() == StateClosed {
+		close(ch)
+		return ch
+	}
+
+	c.stateChanges = append(c.stateChanges, ch)
+	return ch
+}
+
+// WaitReady blocks until the client has connected, ctx is done, or the client
+// is closed.
+func (c *Client) WaitReady(ctx context.Context) error {
+	select {
+	case <-c.ready:
+		return nil
+	case <-c.done:
+		return errClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PingUntilReady keeps creating a client and pinging the server until a PING
+// succeeds or timeout elapses, and returns the ready client. The time left
+// also bounds each attempt's dial and handshake, whatever WithDialTimeout says.
+func PingUntilReady(host string, port int, timeout time.Duration, opts ...option) (*Client, error) {
+	deadline := time.Now().Add(timeout)
+	opts = append(opts[:len(opts):len(opts)], withConnectDeadline(deadline))
+
+	for {
+		client, err := NewClient(host, port, opts...)
+		if err == nil {
+			resp := client.Fire(&wire.Command{Cmd: "PING"})
+			if resp.Status == wire.Status_OK {
+				return client, nil
+			}
+
+			client.Close()
+			err = fmt.Errorf("ping failed: %s", resp.Message)
+		}
+
+		if time.Now().Add(readyPollInterval).After(deadline) {
+			return nil, fmt.Errorf("dicedb server was not ready after %s: %w", timeout, err)
+		}
+
+		time.Sleep(readyPollInterval)
+	}
+}
+
+// withConnectDeadline bounds NewClient's initial connect, all of its dial
+// retries and the handshake, by deadline.
+func withConnectDeadline(deadline time.Time) option {
+	return func(c *Client) {
+		c.connectBy = deadline
+	}
+}
+
+func (c *Client) markReady() {
+	c.setState(StateConnected)
+	c.readyOnce.Do(func() { close(c.ready) })
+}
+
+func (c *Client) connectInBackground() {
+	for {
+		if clientWire, err := c.connect(); err == nil {
+			c.mainMu.Lock()
+			select {
+			case <-c.done:
+				clientWire.Close()
+			default:
+				// Reconnect may have connected the client in the meantime.
+				if c.mainWire != nil {
+					clientWire.Close()
+				} else {
+					c.mainWire = clientWire
+					c.markReady()
+				}
+			}
+			c.mainMu.Unlock()
+			return
+		}
+
+		select {
+		case <-c.done:
+			return
+		case <-time.After(connectRetryInterval):
+		}
+	}
+}
+
+func clientClosed() *ClientError {
+	return clientFailure(ErrConnClosed, "could not fire command: "+errClosed.Error(), errClosed)
+}
+
+func notConnected() *ClientError {
+	return clientFailure(ErrConnClosed, "client is not connected to the server yet", nil)
+}
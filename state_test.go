@@ -0,0 +1,192 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestNewClient_NonBlocking(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithConnectBlocking(false))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.WaitReady(ctx); err != nil {
+		t.Fatalf("WaitReady() error = %v", err)
+	}
+	if got := client.State(); got != StateConnected {
+		t.Errorf("State() = %s, want %s", got, StateConnected)
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() status = %s, want OK: %s", resp.Status, resp.Message)
+	}
+}
+
+func TestNewClient_NonBlockingUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	client, err := NewClient("127.0.0.1", port, WithConnectBlocking(false))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	if got := client.State(); got != StateReconnecting {
+		t.Errorf("State() = %s, want %s", got, StateReconnecting)
+	}
+	if resp := client.Fire(&wire.Command{Cmd: "PING"}); resp.Status != wire.Status_ERR {
+		t.Errorf("Fire() status = %s, want ERR", resp.Status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.WaitReady(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitReady() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestPingUntilReady(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	start := time.Now()
+	if _, err := PingUntilReady("127.0.0.1", port, 300*time.Millisecond); err == nil {
+		t.Fatal("PingUntilReady() error = nil, want error for unreachable server")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("PingUntilReady() took %s, want it bounded by the timeout", elapsed)
+	}
+
+	server := newFakeServer(t, nil)
+	client, err := PingUntilReady(server.host(), server.port(), time.Second)
+	if err != nil {
+		t.Fatalf("PingUntilReady() error = %v", err)
+	}
+	client.Close()
+}
+
+func TestPingUntilReady_BoundsConnect(t *testing.T) {
+	// The listener accepts connections but never answers the handshake.
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer silent.Close()
+	go func() {
+		for {
+			conn, err := silent.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	port := silent.Addr().(*net.TCPAddr).Port
+
+	hangingDial := WithDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	tests := []struct {
+		name string
+		opts []option
+	}{
+		{name: "dial", opts: []option{hangingDial}},
+		{name: "handshake"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			opts := append([]option{WithDialTimeout(5 * time.Second)}, tt.opts...)
+			if _, err := PingUntilReady("127.0.0.1", port, 200*time.Millisecond, opts...); err == nil {
+				t.Fatal("PingUntilReady() error = nil, want error for a server that never gets ready")
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("PingUntilReady() took %s, want it bounded by the timeout", elapsed)
+			}
+		})
+	}
+}
+
+func TestClient_StateChanges(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	changes := client.StateChanges()
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+	client.Close()
+
+	var got []State
+	for s := range changes {
+		got = append(got, s)
+	}
+
+	want := []State{StateReconnecting, StateConnected, StateClosed}
+	if !slices.Equal(got, want) {
+		t.Errorf("StateChanges() got = %v, want %v", got, want)
+	}
+
+	if _, ok := <-client.StateChanges(); ok {
+		t.Errorf("StateChanges() after Close got an open channel, want it closed")
+	}
+}
+
+func TestClient_Close(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("Close() again error = %v, want nil", err)
+	}
+
+	before := len(server.commands())
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := client.Fire(&wire.Command{Cmd: "PING"})
+			if resp.Status != wire.Status_ERR || !strings.Contains(resp.Message, errClosed.Error()) {
+				t.Errorf("Fire() after Close got = %v %q, want a closed-client error", resp.Status, resp.Message)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(server.commands()); got != before {
+		t.Errorf("Fire() after Close sent %d commands, want none", got-before)
+	}
+}
@@ -0,0 +1,69 @@
+package dicedb
+
+import (
+	"context"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// FireStream pipelines the commands received on in and emits their results,
+// in order, on the returned channel. Commands already waiting on in are sent
+// together, up to the batch size set by WithImportBatchSize, so a busy producer
+// gets one round trip per batch rather than per command. The returned channel
+// is closed once in is closed and its last results are emitted, or once ctx is
+// done; results not yet emitted by then are dropped. ctx also bounds each
+// batch, as with FireBatchContext.
+func (c *Client) FireStream(ctx context.Context, in <-chan *wire.Command) <-chan *wire.Result {
+	out := make(chan *wire.Result)
+
+	go func() {
+		defer close(out)
+
+		batchSize := c.batchSize()
+		for {
+			var cmds []*wire.Command
+			select {
+			case cmd, ok := <-in:
+				if !ok {
+					return
+				}
+				cmds = append(cmds, cmd)
+			case <-ctx.Done():
+				return
+			}
+
+			open := true
+		drain:
+			for open && len(cmds) < batchSize {
+				select {
+				case cmd, ok := <-in:
+					if !ok {
+						open = false
+						break drain
+					}
+					cmds = append(cmds, cmd)
+				default:
+					break drain
+				}
+			}
+
+			results, _, err := c.fireBatchContext(ctx, cmds)
+			if err != nil {
+				return
+			}
+			for _, resp := range results {
+				select {
+				case out <- resp:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !open {
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,87 @@
+package dicedb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_FireStream(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "ECHO" {
+			return &wire.Result{Status: wire.Status_OK, Message: "OK", Response: &wire.Result_ECHORes{ECHORes: &wire.ECHORes{Message: cmd.Args[0]}}}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithImportBatchSize(3))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	want := []string{"a", "b", "c", "d", "e", "f", "g"}
+	in := make(chan *wire.Command, len(want))
+	for _, msg := range want {
+		in <- &wire.Command{Cmd: "ECHO", Args: []string{msg}}
+	}
+	close(in)
+
+	var got []string
+	for resp := range client.FireStream(context.Background(), in) {
+		got = append(got, resp.GetECHORes().GetMessage())
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("FireStream() got %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FireStream() result %d got = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestClient_FireStreamCancel(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := client.FireStream(ctx, make(chan *wire.Command))
+	cancel()
+
+	if _, ok := <-out; ok {
+		t.Errorf("FireStream() emitted a result after cancel, want closed channel")
+	}
+}
+
+func TestClient_FireStreamCancelInterruptsBatch(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := slowServer(t, release)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan *wire.Command, 1)
+	in <- &wire.Command{Cmd: "SLOW"}
+	out := client.FireStream(ctx, in)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Errorf("FireStream() emitted a result after cancel, want closed channel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("FireStream() kept waiting on the batch after cancel")
+	}
+}
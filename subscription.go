@@ -0,0 +1,440 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// subscription routes watch updates carrying a given fingerprint to a single
+// consumer. The fingerprint is the one the server returns in reply to the
+// .WATCH command and repeats on every update pushed for it.
+type subscription struct {
+	cmd         *wire.Command
+	fingerprint uint64
+	handler     func(*wire.Result)
+	onClose     func()
+	cancelled   atomic.Bool
+	done        chan struct{}
+	mu          sync.Mutex
+	running     bool  // a handler call is under way; guarded by mu
+	err         error // why the subscription ended, if not cancelled
+}
+
+func newSubscription(cmd *wire.Command) *subscription {
+	return &subscription{cmd: cmd, done: make(chan struct{})}
+}
+
+// deliver runs the handler without holding mu, so the handler may close its
+// own subscription. Deliveries come from the watch goroutine alone, so at
+// most one handler call runs at a time.
+func (s *subscription) deliver(res *wire.Result, log Logger) {
+	s.mu.Lock()
+	if s.cancelled.Load() {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("watch handler panicked", "fingerprint", s.fingerprint, "panic", r)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.running = false
+		// A close during the call left onClose for the handler's return.
+		if s.cancelled.Load() && s.onClose != nil {
+			s.onClose()
+		}
+	}()
+
+	s.handler(res)
+}
+
+// close stops delivery and reports whether this call was the one that closed
+// the subscription. err records why it ended and is nil when it was
+// cancelled. close does not wait for a handler already running, which may be
+// the caller itself; onClose then runs once that handler returns. Handlers
+// that block should select on done so they return promptly.
+func (s *subscription) close(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancelled.Swap(true) {
+		return false
+	}
+
+	close(s.done)
+	s.err = err
+	if !s.running && s.onClose != nil {
+		s.onClose()
+	}
+
+	return true
+}
+
+// OnWatch watches key and invokes handler for every update pushed by the
+// server. Handlers run on the watch goroutine one at a time, so a slow handler
+// delays the ones after it. Calling cancel unwatches the key; it may be called
+// at any time, including from inside handler.
+func (c *Client) OnWatch(key string, handler func(*wire.Result)) (cancel func(), err error) {
+	sub := newSubscription(&wire.Command{Cmd: "GET.WATCH", Args: []string{c.key(key)}})
+	sub.handler = handler
+	if _, err := c.subscribe(sub); err != nil {
+		return nil, err
+	}
+
+	return func() { c.release(sub) }, nil
+}
+
+// WatchCommand fires cmd, which must be one of the .WATCH commands, and
+// returns a channel receiving every update the server pushes for it. The
+// channel is closed once the watch is removed with UnwatchAll or the watch
+// connection terminates.
+func (c *Client) WatchCommand(cmd *wire.Command) (<-chan *wire.Result, error) {
+	ch, _, err := c.watchCommand(cmd)
+	return ch, err
+}
+
+func (c *Client) watchCommand(cmd *wire.Command) (<-chan *wire.Result, *subscription, error) {
+	ch := c.newWatchChan()
+	sub := newSubscription(cmd)
+	sub.handler = func(res *wire.Result) {
+		c.offer(ch, res, sub.done)
+	}
+	sub.onClose = func() { close(ch) }
+
+	if _, err := c.subscribe(sub); err != nil {
+		return nil, nil, err
+	}
+
+	return ch, sub, nil
+}
+
+// WatchCommandContext is WatchCommand for as long as ctx lasts. Once ctx is
+// done the command is unwatched and the channel closed.
+func (c *Client) WatchCommandContext(ctx context.Context, cmd *wire.Command) (<-chan *wire.Result, error) {
+	return c.watchUntil(ctx, func() {}, cmd)
+}
+
+// WatchCommandWithDeadline is WatchCommand lasting at most d, after which the
+// command is unwatched and the channel closed.
+func (c *Client) WatchCommandWithDeadline(cmd *wire.Command, d time.Duration) (<-chan *wire.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	ch, err := c.watchUntil(ctx, cancel, cmd)
+	if err != nil {
+		cancel()
+	}
+
+	return ch, err
+}
+
+// watchUntil watches cmd until ctx is done, then calls release. release also
+// runs when the watch ends first, e.g. with UnwatchAll.
+func (c *Client) watchUntil(ctx context.Context, release func(), cmd *wire.Command) (<-chan *wire.Result, error) {
+	ch, sub, err := c.watchCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer release()
+		select {
+		case <-ctx.Done():
+			c.release(sub)
+		case <-sub.done:
+		}
+	}()
+
+	return ch, nil
+}
+
+// WaitForKey blocks until key exists and returns its value as read by
+// GET.WATCH. The watch is removed before returning, including when ctx is
+// done first.
+func (c *Client) WaitForKey(ctx context.Context, key string) (*wire.Result, error) {
+	updates := make(chan *wire.Result, 1)
+	sub := newSubscription(&wire.Command{Cmd: "GET.WATCH", Args: []string{c.key(key)}})
+	sub.handler = func(res *wire.Result) {
+		select {
+		case updates <- res:
+		default:
+		}
+	}
+	resp, err := c.subscribe(sub)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(sub)
+
+	// The key may have been set before the watch was registered.
+	for {
+		exists, err := c.exists(key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return resp, nil
+		}
+
+		select {
+		case resp = <-updates:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// subscribe fires the subscription's command and registers it for the
+// updates that follow, returning the command's own reply.
+func (c *Client) subscribe(sub *subscription) (*wire.Result, error) {
+	if err := c.startWatch(context.Background()); err != nil {
+		return nil, err
+	}
+
+	resp := c.Fire(sub.cmd)
+	if resp.Status == wire.Status_ERR {
+		return nil, fmt.Errorf("could not subscribe to %s: %s", sub.cmd.Cmd, resp.Message)
+	}
+
+	sub.fingerprint = resp.Fingerprint64
+	c.register(sub)
+
+	return resp, nil
+}
+
+func (c *Client) register(sub *subscription) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	c.addSub(sub)
+}
+
+// unregister removes sub from the routing table and returns how many
+// subscriptions remain for its fingerprint.
+func (c *Client) unregister(sub *subscription) int {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	return c.removeSub(sub)
+}
+
+// addSub and removeSub update the routing table. Callers must hold watchMu.
+func (c *Client) addSub(sub *subscription) {
+	if c.subs == nil {
+		c.subs = make(map[uint64][]*subscription)
+	}
+	c.subs[sub.fingerprint] = append(c.subs[sub.fingerprint], sub)
+}
+
+func (c *Client) removeSub(sub *subscription) int {
+	subs := c.subs[sub.fingerprint]
+	for i, s := range subs {
+		if s == sub {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(c.subs, sub.fingerprint)
+	} else {
+		c.subs[sub.fingerprint] = subs
+	}
+
+	return len(subs)
+}
+
+// unsubscribe closes sub and unwatches it on the server once no other
+// subscription shares its fingerprint.
+func (c *Client) unsubscribe(sub *subscription) error {
+	if !sub.close(nil) {
+		return nil
+	}
+
+	// Other subscribers still share the server-side watch.
+	if c.unregister(sub) > 0 {
+		return nil
+	}
+
+	return c.unwatch(sub.fingerprint)
+}
+
+// release is unsubscribe for callers with no one to report the error to.
+func (c *Client) release(sub *subscription) {
+	if err := c.unsubscribe(sub); err != nil {
+		c.logger().Warn("failed to unwatch", "fingerprint", sub.fingerprint, "error", err)
+	}
+}
+
+// UnwatchAll unwatches every subscription registered on the client. The watch
+// connection stays open, so new subscriptions can be made afterwards.
+func (c *Client) UnwatchAll() error {
+	c.watchMu.Lock()
+	subs := c.subs
+	c.subs = nil
+	c.watchMu.Unlock()
+
+	var errs []error
+	for fingerprint, group := range subs {
+		for _, sub := range group {
+			sub.close(nil)
+		}
+
+		if err := c.unwatch(fingerprint); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) unwatch(fingerprint uint64) error {
+	resp := c.Fire(&wire.Command{
+		Cmd:  "UNWATCH",
+		Args: []string{strconv.FormatUint(fingerprint, 10)},
+	})
+	if resp.Status == wire.Status_ERR {
+		return fmt.Errorf("could not unwatch %d: %s", fingerprint, resp.Message)
+	}
+
+	return nil
+}
+
+// Subscription is a single watch made with Watch. Updates arrive on Ch until
+// Close is called or the watch ends on its own, for instance because the watch
+// connection could not be restored, in which case Err says why.
+type Subscription struct {
+	client *Client
+	sub    *subscription
+	ch     <-chan *wire.Result
+}
+
+// Watch fires cmd, which must be one of the .WATCH commands, and returns a
+// Subscription receiving every update the server pushes for it, including
+// after the watch connection reconnects. Each call is independent of the
+// others and of the channel returned by WatchCh.
+func (c *Client) Watch(cmd *wire.Command) (*Subscription, error) {
+	ch, sub, err := c.watchCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{client: c, sub: sub, ch: ch}, nil
+}
+
+// Ch returns the channel receiving the subscription's updates. It is closed
+// once the subscription ends.
+func (s *Subscription) Ch() <-chan *wire.Result {
+	return s.ch
+}
+
+// Close stops delivery, closes the channel from Ch and unwatches the command
+// on the server, returning the error if the server refused. The client and
+// its other subscriptions are unaffected. Closing an ended subscription does
+// nothing.
+func (s *Subscription) Close() error {
+	return s.client.unsubscribe(s.sub)
+}
+
+// Err returns why the subscription ended on its own, or nil while it is live
+// or after Close.
+func (s *Subscription) Err() error {
+	s.sub.mu.Lock()
+	defer s.sub.mu.Unlock()
+
+	return s.sub.err
+}
+
+// resubscribe fires the command of every registered subscription again, for
+// after the watch connection was restored and the server forgot about them.
+// Subscriptions the server now refuses are closed rather than left silent.
+func (c *Client) resubscribe() {
+	c.watchMu.Lock()
+	groups := make([][]*subscription, 0, len(c.subs))
+	for _, group := range c.subs {
+		groups = append(groups, append([]*subscription(nil), group...))
+	}
+	c.watchMu.Unlock()
+
+	for _, group := range groups {
+		cmd := group[0].cmd
+		resp := c.Fire(cmd)
+		if resp.Status == wire.Status_ERR {
+			// The command connection usually dropped along with the watch
+			// one, and the first command sent on it is what finds out.
+			// Firing a .WATCH twice is harmless, so try once more.
+			resp = c.Fire(cmd)
+		}
+		if resp.Status == wire.Status_ERR {
+			c.logger().Warn("failed to restore watch", "cmd", cmd.Cmd, "error", resp.Message)
+			err := fmt.Errorf("could not restore watch for %s: %s", cmd.Cmd, resp.Message)
+			for _, sub := range group {
+				c.unregister(sub)
+				sub.close(err)
+			}
+			continue
+		}
+
+		if resp.Fingerprint64 == group[0].fingerprint {
+			continue
+		}
+
+		c.watchMu.Lock()
+		for _, sub := range group {
+			// A subscription cancelled meanwhile is no longer in the table.
+			if sub.cancelled.Load() {
+				continue
+			}
+			c.removeSub(sub)
+			sub.fingerprint = resp.Fingerprint64
+			c.addSub(sub)
+		}
+		c.watchMu.Unlock()
+	}
+}
+
+// closeSubscriptions stops every subscription without unwatching it on the
+// server, for when the watch connection itself is gone. err is nil when the
+// connection was stopped on purpose.
+func (c *Client) closeSubscriptions(err error) {
+	c.watchMu.Lock()
+	subs := c.subs
+	c.subs = nil
+	c.watchMu.Unlock()
+
+	for _, group := range subs {
+		for _, sub := range group {
+			sub.close(err)
+		}
+	}
+}
+
+// dispatch hands a watch update to the subscriptions registered for its
+// fingerprint, falling back to the channel returned by WatchCh.
+func (c *Client) dispatch(res *wire.Result) {
+	c.watchMu.Lock()
+	subs := append([]*subscription(nil), c.subs[res.Fingerprint64]...)
+	ch := c.watchCh
+	c.watchMu.Unlock()
+
+	if len(subs) > 0 {
+		log := c.logger()
+		for _, sub := range subs {
+			sub.deliver(res, log)
+		}
+		return
+	}
+
+	if ch != nil {
+		c.offer(ch, res, c.done)
+	}
+}
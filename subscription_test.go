@@ -0,0 +1,497 @@
+package dicedb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func watchHandler(cmd *wire.Command) *wire.Result {
+	if cmd.Cmd == "GET.WATCH" {
+		return &wire.Result{Status: wire.Status_OK, Fingerprint64: 42}
+	}
+	return nil
+}
+
+func TestOnWatch(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	got := make(chan string, 2)
+	cancel, err := client.OnWatch("k1", func(res *wire.Result) {
+		value := res.GetGETRes().GetValue()
+		got <- value
+		if value == "boom" {
+			panic("handler failure")
+		}
+	})
+	if err != nil {
+		t.Fatalf("OnWatch() error = %v", err)
+	}
+
+	for _, value := range []string{"boom", "v2"} {
+		server.push(&wire.Result{
+			Status:        wire.Status_OK,
+			Fingerprint64: 42,
+			Response:      &wire.Result_GETRes{GETRes: &wire.GETRes{Value: value}},
+		})
+
+		select {
+		case v := <-got:
+			if v != value {
+				t.Errorf("OnWatch() handler got = %s, want %s", v, value)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("OnWatch() handler was not invoked for %s", value)
+		}
+	}
+
+	cancel()
+
+	cmds := server.commands()
+	last := cmds[len(cmds)-1]
+	if last.Cmd != "UNWATCH" || len(last.Args) != 1 || last.Args[0] != "42" {
+		t.Errorf("cancel() sent %v, want UNWATCH 42", last)
+	}
+}
+
+func TestOnWatchCancelFromHandler(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	cancelled := make(chan struct{})
+	var cancel func()
+	var ready sync.WaitGroup
+	ready.Add(1)
+	cancel, err = client.OnWatch("k1", func(*wire.Result) {
+		ready.Wait()
+		cancel()
+		close(cancelled)
+	})
+	if err != nil {
+		t.Fatalf("OnWatch() error = %v", err)
+	}
+	ready.Done()
+
+	server.push(&wire.Result{
+		Status:        wire.Status_OK,
+		Fingerprint64: 42,
+		Response:      &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v1"}},
+	})
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("cancel() called from the handler did not return")
+	}
+
+	var unwatched bool
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "UNWATCH" {
+			unwatched = true
+		}
+	}
+	if !unwatched {
+		t.Error("cancel() from the handler did not send UNWATCH")
+	}
+}
+
+func TestWaitForKey(t *testing.T) {
+	var exists atomic.Int64
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "EXISTS" {
+			return &wire.Result{Status: wire.Status_OK, Response: &wire.Result_EXISTSRes{EXISTSRes: &wire.EXISTSRes{Count: exists.Load()}}}
+		}
+		return watchHandler(cmd)
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForKey(ctx, "k1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForKey() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	done := make(chan *wire.Result, 1)
+	go func() {
+		res, err := client.WaitForKey(context.Background(), "k1")
+		if err != nil {
+			t.Errorf("WaitForKey() error = %v", err)
+		}
+		done <- res
+	}()
+
+	for {
+		exists.Store(1)
+		server.push(&wire.Result{
+			Status:        wire.Status_OK,
+			Fingerprint64: 42,
+			Response:      &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v1"}},
+		})
+
+		select {
+		case res := <-done:
+			if res == nil {
+				t.Fatal("WaitForKey() returned nil result")
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestWatchCommand(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.WatchCommand(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}})
+	if err != nil {
+		t.Fatalf("WatchCommand() error = %v", err)
+	}
+
+	server.push(&wire.Result{Status: wire.Status_OK, Fingerprint64: 42, Message: "update"})
+	select {
+	case res := <-ch:
+		if res.Message != "update" {
+			t.Errorf("WatchCommand() got = %v, want update", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchCommand() did not deliver the update")
+	}
+
+	if err := client.UnwatchAll(); err != nil {
+		t.Fatalf("UnwatchAll() error = %v", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("WatchCommand() channel still open after UnwatchAll()")
+	}
+}
+
+func TestWatchCommandWithDeadline(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.WatchCommandWithDeadline(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}}, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchCommandWithDeadline() error = %v", err)
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("WatchCommandWithDeadline() delivered an update, want the channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchCommandWithDeadline() channel still open after the deadline")
+	}
+
+	// The channel closes just before UNWATCH goes out.
+	unwatched := func() bool {
+		for _, cmd := range server.commands() {
+			if cmd.Cmd == "UNWATCH" && len(cmd.Args) == 1 && cmd.Args[0] == "42" {
+				return true
+			}
+		}
+		return false
+	}
+	for deadline := time.Now().Add(time.Second); !unwatched() && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !unwatched() {
+		t.Error("WatchCommandWithDeadline() did not unwatch the fingerprint on the server")
+	}
+}
+
+func TestWatchChContext(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := client.WatchChContext(ctx)
+	if err != nil {
+		t.Fatalf("WatchChContext() error = %v", err)
+	}
+
+	// The server stays up, so only the cancellation can end the pending read.
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("WatchChContext() delivered an update, want the channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchChContext() channel still open after cancel")
+	}
+}
+
+func TestWatchChContext_Shared(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	short, cancelShort := context.WithCancel(context.Background())
+	long, cancelLong := context.WithCancel(context.Background())
+	defer cancelLong()
+	if _, err := client.WatchChContext(short); err != nil {
+		t.Fatalf("WatchChContext() error = %v", err)
+	}
+	ch, err := client.WatchChContext(long)
+	if err != nil {
+		t.Fatalf("WatchChContext() error = %v", err)
+	}
+
+	// The long-lived caller still holds the connection open.
+	cancelShort()
+	server.push(&wire.Result{Status: wire.Status_OK, Fingerprint64: 7, Message: "update"})
+	select {
+	case resp, ok := <-ch:
+		if !ok || resp.Fingerprint64 != 7 {
+			t.Fatalf("WatchChContext() got = %v, %v, want the pushed update", resp, ok)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchChContext() delivered nothing after another caller's ctx ended")
+	}
+
+	cancelLong()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("WatchChContext() delivered an update, want the channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchChContext() channel still open after every ctx ended")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	sub, err := client.Watch(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	server.push(&wire.Result{
+		Status:        wire.Status_OK,
+		Fingerprint64: 42,
+		Response:      &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v1"}},
+	})
+
+	select {
+	case res := <-sub.Ch():
+		if got := res.GetGETRes().GetValue(); got != "v1" {
+			t.Errorf("Subscription.Ch() got = %s, want v1", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Subscription.Ch() got nothing for a registered watch")
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Errorf("Subscription.Close() error = %v", err)
+	}
+	if _, ok := <-sub.Ch(); ok {
+		t.Error("Subscription.Ch() still open after Close")
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("Subscription.Err() after Close got = %v, want nil", err)
+	}
+
+	var unwatched bool
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "UNWATCH" && len(cmd.Args) == 1 && cmd.Args[0] == "42" {
+			unwatched = true
+		}
+	}
+	if !unwatched {
+		t.Error("Subscription.Close() did not send UNWATCH 42")
+	}
+}
+
+func TestSubscription_Err(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port(), WithDialTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	sub, err := client.Watch(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// With the listener gone the watch connection cannot be restored.
+	server.Close()
+
+	select {
+	case _, ok := <-sub.Ch():
+		if ok {
+			t.Fatal("Subscription.Ch() delivered an update, want the channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Subscription.Ch() still open after the server went away")
+	}
+
+	if err := sub.Err(); err == nil {
+		t.Error("Subscription.Err() after termination got = nil, want the terminating error")
+	}
+}
+
+func TestWatchResubscribesAfterReconnect(t *testing.T) {
+	var watches atomic.Int64
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET.WATCH" {
+			// The server hands out a new fingerprint for the replayed watch.
+			return &wire.Result{Status: wire.Status_OK, Fingerprint64: uint64(40 + watches.Add(1))}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.WatchCommand(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}})
+	if err != nil {
+		t.Fatalf("WatchCommand() error = %v", err)
+	}
+
+	server.dropConns()
+
+	deadline := time.Now().Add(time.Second)
+	for watches.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("GET.WATCH was not replayed after the watch connection dropped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The replay may have finished before its new watch connection registered
+	// with the server, so keep pushing until the update gets through.
+	update := &wire.Result{
+		Status:        wire.Status_OK,
+		Fingerprint64: 42,
+		Response:      &wire.Result_GETRes{GETRes: &wire.GETRes{Value: "v2"}},
+	}
+	for {
+		server.push(update)
+		select {
+		case res := <-ch:
+			if got := res.GetGETRes().GetValue(); got != "v2" {
+				t.Errorf("WatchCommand() after reconnect got = %s, want v2", got)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("WatchCommand() channel got nothing after reconnect")
+		}
+	}
+}
+
+func TestWatchErr(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port(), WithDialTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.WatchCh()
+	if err != nil {
+		t.Fatalf("WatchCh() error = %v", err)
+	}
+	if err := client.WatchErr(); err != nil {
+		t.Errorf("WatchErr() while watching got = %v, want nil", err)
+	}
+
+	// With the listener gone the watch connection cannot be restored.
+	server.Close()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("WatchCh() delivered an update, want the channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchCh() channel still open after the server went away")
+	}
+
+	if err := client.WatchErr(); err == nil {
+		t.Error("WatchErr() after termination got = nil, want the terminating error")
+	}
+}
+
+func TestCloseStopsWatch(t *testing.T) {
+	server := newFakeServer(t, watchHandler)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ch, err := client.WatchCh()
+	if err != nil {
+		t.Fatalf("WatchCh() error = %v", err)
+	}
+	sub, err := client.WatchCommand(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k1"}})
+	if err != nil {
+		t.Fatalf("WatchCommand() error = %v", err)
+	}
+
+	client.Close()
+	client.Close()
+
+	for name, c := range map[string]<-chan *wire.Result{"WatchCh": ch, "WatchCommand": sub} {
+		select {
+		case _, ok := <-c:
+			if ok {
+				t.Errorf("%s() delivered an update, want the channel closed", name)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s() channel still open after Close()", name)
+		}
+	}
+
+	if _, err := client.WatchCh(); err == nil {
+		t.Error("WatchCh() after Close() error = nil, want error")
+	}
+}
@@ -0,0 +1,122 @@
+package dicedb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// Tokenizer splits the input of FireString into a command and its arguments.
+type Tokenizer func(cmdStr string) (cmd string, args []string, err error)
+
+// WithTokenizer replaces the tokenizer FireString uses to parse its input.
+func WithTokenizer(tokenizer Tokenizer) option {
+	return func(c *Client) {
+		c.tokenizer = tokenizer
+	}
+}
+
+// shellTokenizer splits cmdStr on runs of whitespace like a shell would.
+// Single quotes keep their contents verbatim, double quotes keep them apart
+// from backslash escapes, and a backslash outside quotes escapes the next
+// character, so `SET greeting "hello world"` has the args greeting and
+// hello world.
+func shellTokenizer(cmdStr string) (string, []string, error) {
+	var tokens []string
+	var token strings.Builder
+	inToken := false
+	var quote rune
+	escaped := false
+
+	for _, r := range cmdStr {
+		switch {
+		case escaped:
+			token.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inToken = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				token.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	switch {
+	case escaped:
+		return "", nil, errors.New("trailing backslash")
+	case quote != 0:
+		return "", nil, fmt.Errorf("unmatched %c quote", quote)
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+	if len(tokens) == 0 {
+		return "", nil, errors.New("empty command")
+	}
+
+	var args []string
+	if len(tokens) > 1 {
+		args = tokens[1:]
+	}
+
+	return tokens[0], args, nil
+}
+
+func (c *Client) tokenize(cmdStr string) (string, []string, error) {
+	if c.tokenizer != nil {
+		return c.tokenizer(cmdStr)
+	}
+
+	return shellTokenizer(cmdStr)
+}
+
+// FireString parses cmdStr with the client's tokenizer and fires the result.
+// The default tokenizer understands shell-style quoting and escapes, which
+// suits commands typed by hand; for arguments already split use FireTokens,
+// and for binary data FireRaw.
+func (c *Client) FireString(cmdStr string) *wire.Result {
+	cmd, args, err := c.tokenize(cmdStr)
+	if err != nil {
+		return clientFailure(ErrInvalidCommand, "could not parse command: "+err.Error(), err).result()
+	}
+
+	return c.Fire(&wire.Command{
+		Cmd:  cmd,
+		Args: args,
+	})
+}
+
+// FireTokens fires tokens[0] as the command and the remaining tokens as its
+// arguments, exactly as given, with no parsing of any kind. Use it when the
+// arguments are already split, or hold spaces and quotes FireString would
+// interpret. Tokens must be valid UTF-8, or the command fails to encode and
+// nothing is sent; see FireRaw for binary data.
+func (c *Client) FireTokens(tokens ...string) *wire.Result {
+	if len(tokens) == 0 {
+		return clientFailure(ErrInvalidCommand, "could not fire command: no tokens given", nil).result()
+	}
+
+	return c.Fire(&wire.Command{
+		Cmd:  tokens[0],
+		Args: tokens[1:],
+	})
+}
@@ -0,0 +1,82 @@
+package dicedb
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_FireTokens(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 1, WithDryRun())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	args := []string{"k", "two  spaces \"quoted\"", "\x00 naïve ✓"}
+	client.FireTokens(append([]string{"SET"}, args...)...)
+
+	got := client.RecordedCommands()
+	if len(got) != 1 || got[0].Cmd != "SET" || !slices.Equal(got[0].Args, args) {
+		t.Errorf("FireTokens() sent = %v, want SET %q", got, args)
+	}
+
+	if resp := client.FireTokens(); resp.Status != wire.Status_ERR {
+		t.Errorf("FireTokens() with no tokens got = %v, want an error result", resp)
+	}
+}
+
+func TestClient_FireTokensInvalidUTF8(t *testing.T) {
+	server := newFakeServer(t, nil)
+	client, err := NewClient(server.host(), server.port())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if resp := client.FireTokens("SET", "k", "\xff"); resp.Status != wire.Status_ERR {
+		t.Errorf("FireTokens() with invalid UTF-8 got = %v, want an error result", resp)
+	}
+	for _, cmd := range server.commands() {
+		if cmd.Cmd == "SET" {
+			t.Errorf("server got %v, want the command not sent", cmd)
+		}
+	}
+}
+
+func TestShellTokenizer(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantCmd  string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{name: "plain", input: "GET k", wantCmd: "GET", wantArgs: []string{"k"}},
+		{name: "no args", input: "PING", wantCmd: "PING"},
+		{name: "runs of spaces", input: "  SET   k \t v  ", wantCmd: "SET", wantArgs: []string{"k", "v"}},
+		{name: "double quotes", input: `SET greeting "hello world"`, wantCmd: "SET", wantArgs: []string{"greeting", "hello world"}},
+		{name: "single quotes", input: `SET k 'a \"b\" c'`, wantCmd: "SET", wantArgs: []string{"k", `a \"b\" c`}},
+		{name: "escaped quote", input: `SET k "say \"hi\""`, wantCmd: "SET", wantArgs: []string{"k", `say "hi"`}},
+		{name: "escaped space", input: `SET k hello\ world`, wantCmd: "SET", wantArgs: []string{"k", "hello world"}},
+		{name: "empty quoted arg", input: `SET k ""`, wantCmd: "SET", wantArgs: []string{"k", ""}},
+		{name: "adjacent quotes join", input: `SET k a"b c"d`, wantCmd: "SET", wantArgs: []string{"k", "ab cd"}},
+		{name: "empty", input: "", wantErr: true},
+		{name: "only spaces", input: "   ", wantErr: true},
+		{name: "unmatched double quote", input: `SET k "oops`, wantErr: true},
+		{name: "unmatched single quote", input: `SET k 'oops`, wantErr: true},
+		{name: "trailing backslash", input: `SET k v\`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, args, err := shellTokenizer(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("shellTokenizer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cmd != tt.wantCmd || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("shellTokenizer() got = %q %q, want %q %q", cmd, args, tt.wantCmd, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package dicedb
+
+import (
+	"context"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/dicedb/dicedb-go"
+
+// WithTracing makes FireContext start an OpenTelemetry span for each command
+// whose ctx carries a recording span. The child span is named after the
+// command, uses that span's tracer provider, and records the final status,
+// being marked as an error for Status_ERR results. Commands fired without a
+// span in ctx, and every command on clients without this option, are not
+// traced.
+func WithTracing() option {
+	return func(c *Client) {
+		c.tracing = true
+	}
+}
+
+// startSpan starts the span for cmd, returning nil when ctx is not being
+// traced.
+func (c *Client) startSpan(ctx context.Context, cmd *wire.Command) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, nil
+	}
+
+	return parent.TracerProvider().Tracer(tracerName).Start(ctx, cmd.Cmd,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "dicedb"),
+			attribute.String("db.operation.name", cmd.Cmd),
+		),
+	)
+}
+
+func endSpan(span trace.Span, resp *wire.Result) {
+	span.SetAttributes(attribute.String("dicedb.status", resp.Status.String()))
+	if resp.Status == wire.Status_ERR {
+		span.SetStatus(codes.Error, resp.Message)
+	}
+	span.End()
+}
@@ -0,0 +1,68 @@
+package dicedb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClient_WithTracing(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "BAD" {
+			return &wire.Result{Status: wire.Status_ERR, Message: "ERR unknown command"}
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithTracing())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+
+	client.FireContext(ctx, &wire.Command{Cmd: "PING"})
+	client.FireContext(ctx, &wire.Command{Cmd: "BAD"})
+	// Without a span in ctx nothing is traced.
+	client.Fire(&wire.Command{Cmd: "PING"})
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3: two commands and the parent", len(spans))
+	}
+
+	tests := []struct {
+		name   string
+		status string
+		code   codes.Code
+	}{
+		{name: "PING", status: "OK", code: codes.Unset},
+		{name: "BAD", status: "ERR", code: codes.Error},
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Name() != tt.name || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %d got = %s under %s, want %s under the parent", i, span.Name(), span.Parent().SpanID(), tt.name)
+		}
+		if span.Status().Code != tt.code {
+			t.Errorf("span %s status got = %v, want %v", tt.name, span.Status().Code, tt.code)
+		}
+
+		var status string
+		for _, attr := range span.Attributes() {
+			if attr.Key == "dicedb.status" {
+				status = attr.Value.AsString()
+			}
+		}
+		if status != tt.status {
+			t.Errorf("span %s dicedb.status got = %q, want %q", tt.name, status, tt.status)
+		}
+	}
+}
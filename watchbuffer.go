@@ -0,0 +1,95 @@
+package dicedb
+
+import "github.com/dicedb/dicedb-go/wire"
+
+const defaultWatchBufferSize = 64
+
+// OverflowPolicy decides what happens to a watch update when the channel it
+// is bound for is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the consumer to make room. No update is lost,
+	// but while it waits the watch goroutine reads nothing from the server,
+	// so every other watch on the connection stalls with it.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered update to make room, so
+	// the consumer always sees the latest ones.
+	OverflowDropOldest
+	// OverflowDropNewest discards the incoming update, so the consumer sees
+	// the buffered ones and misses what arrived while it was behind.
+	OverflowDropNewest
+)
+
+// WithWatchBufferSize sets how many updates the channels from WatchCh,
+// WatchCommand and Watch buffer before the overflow policy applies. A zero or
+// negative size keeps the default of 64.
+func WithWatchBufferSize(size int) option {
+	return func(c *Client) {
+		c.watchBufferSize = size
+	}
+}
+
+// WithWatchOverflow sets what happens to an update when its channel is full.
+// The default, OverflowBlock, never loses an update but lets one slow consumer
+// hold up the whole watch connection; the drop policies keep the connection
+// reading and count every discarded update in DroppedWatchUpdates. Handlers
+// given to OnWatch are called directly and are not affected.
+func WithWatchOverflow(policy OverflowPolicy) option {
+	return func(c *Client) {
+		c.watchOverflow = policy
+	}
+}
+
+// DroppedWatchUpdates returns how many watch updates the overflow policy has
+// discarded since the client was created.
+func (c *Client) DroppedWatchUpdates() int64 {
+	return c.watchDropped.Load()
+}
+
+func (c *Client) watchBuffer() int {
+	if c.watchBufferSize <= 0 {
+		return defaultWatchBufferSize
+	}
+	return c.watchBufferSize
+}
+
+func (c *Client) newWatchChan() chan *wire.Result {
+	return make(chan *wire.Result, c.watchBuffer())
+}
+
+// offer sends res on ch according to the overflow policy. Under OverflowBlock
+// it gives up once done is closed. ch must only be sent on, and closed, by the
+// caller's goroutine, so a slot freed here cannot be taken by another sender.
+func (c *Client) offer(ch chan *wire.Result, res *wire.Result, done <-chan struct{}) {
+	switch c.watchOverflow {
+	case OverflowDropNewest:
+		select {
+		case ch <- res:
+		default:
+			c.watchDropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case ch <- res:
+				return
+			default:
+			}
+
+			// The consumer may have emptied the buffer in the meantime, in
+			// which case the send is retried without dropping anything.
+			select {
+			case <-ch:
+			default:
+				continue
+			}
+			c.watchDropped.Add(1)
+		}
+	default:
+		select {
+		case ch <- res:
+		case <-done:
+		}
+	}
+}
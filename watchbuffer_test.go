@@ -0,0 +1,62 @@
+package dicedb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestWatchOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy OverflowPolicy
+		want   []string
+	}{
+		{name: "drop oldest", policy: OverflowDropOldest, want: []string{"v3", "v4"}},
+		{name: "drop newest", policy: OverflowDropNewest, want: []string{"v1", "v2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, nil)
+			client, err := NewClient(server.host(), server.port(), WithWatchBufferSize(2), WithWatchOverflow(tt.policy))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			ch, err := client.WatchCh()
+			if err != nil {
+				t.Fatalf("WatchCh() error = %v", err)
+			}
+
+			for i := 1; i <= 4; i++ {
+				server.push(&wire.Result{
+					Status:   wire.Status_OK,
+					Response: &wire.Result_GETRes{GETRes: &wire.GETRes{Value: fmt.Sprintf("v%d", i)}},
+				})
+			}
+
+			deadline := time.Now().Add(time.Second)
+			for client.DroppedWatchUpdates() < 2 {
+				if time.Now().After(deadline) {
+					t.Fatalf("DroppedWatchUpdates() got = %d, want 2", client.DroppedWatchUpdates())
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			for _, want := range tt.want {
+				select {
+				case res := <-ch:
+					if got := res.GetGETRes().GetValue(); got != want {
+						t.Errorf("WatchCh() got = %s, want %s", got, want)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("WatchCh() got nothing, want %s", want)
+				}
+			}
+		})
+	}
+}
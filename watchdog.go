@@ -0,0 +1,33 @@
+package dicedb
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+const maxStackDumpSize = 1 << 20 // 1 MB
+
+// WithStuckCommandWatchdog reports every Fire call still waiting on its reply
+// after threshold. The report is logged with the stacks of all goroutines and
+// recorded as an EventCommandStuck event; the call itself carries on.
+func WithStuckCommandWatchdog(threshold time.Duration) option {
+	return func(c *Client) {
+		c.stuckAfter = threshold
+	}
+}
+
+// watchdog arms a timer reporting cmd as stuck. The caller stops it once cmd
+// completes, so a command that finishes in time only costs the timer.
+func (c *Client) watchdog(cmd *wire.Command) *time.Timer {
+	start := time.Now()
+	return time.AfterFunc(c.stuckAfter, func() {
+		buf := make([]byte, maxStackDumpSize)
+		buf = buf[:runtime.Stack(buf, true)]
+
+		c.logger().Warn("command is stuck", "cmd", cmd.Cmd, "waiting", time.Since(start), "stacks", string(buf))
+		c.events.record(EventCommandStuck, connCommand, fmt.Errorf("%s has been waiting on its reply for %s", cmd.Cmd, c.stuckAfter))
+	})
+}
@@ -0,0 +1,45 @@
+package dicedb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func TestClient_StuckCommandWatchdog(t *testing.T) {
+	server := newFakeServer(t, func(cmd *wire.Command) *wire.Result {
+		if cmd.Cmd == "GET" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return nil
+	})
+	client, err := NewClient(server.host(), server.port(), WithStuckCommandWatchdog(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	stuck := func() int {
+		n := 0
+		for _, e := range client.RecentEvents() {
+			if e.Kind == EventCommandStuck {
+				n++
+			}
+		}
+		return n
+	}
+
+	client.Fire(&wire.Command{Cmd: "PING"})
+	time.Sleep(100 * time.Millisecond)
+	if got := stuck(); got != 0 {
+		t.Errorf("stuck events after PING got = %d, want 0", got)
+	}
+
+	if resp := client.Fire(&wire.Command{Cmd: "GET", Args: []string{"k"}}); resp.Status != wire.Status_OK {
+		t.Errorf("Fire() status = %v, want %v", resp.Status, wire.Status_OK)
+	}
+	if got := stuck(); got != 1 {
+		t.Errorf("stuck events after GET got = %d, want 1", got)
+	}
+}
@@ -7,6 +7,7 @@ const (
 	Empty          ErrKind = 2
 	Terminated     ErrKind = 3
 	CorruptMessage ErrKind = 4
+	Timeout        ErrKind = 5
 )
 
 type WireError struct {
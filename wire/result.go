@@ -0,0 +1,117 @@
+package wire
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Equal reports whether r and other carry the same status, message,
+// fingerprint and response. Protobuf bookkeeping such as cached sizes does
+// not take part in the comparison.
+func (r *Result) Equal(other *Result) bool {
+	return proto.Equal(r, other)
+}
+
+// Diff describes every field that differs between r and other, one entry per
+// field in the form "path: old != new". Nested fields are named by their
+// dotted path, and repeated fields by index, e.g. "ZRANGERes.elements[1].score".
+// It returns nil when the results are equal.
+func (r *Result) Diff(other *Result) []string {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return []string{fmt.Sprintf("result: %s != %s", formatResult(r), formatResult(other))}
+	}
+
+	return diffMessage("", r.ProtoReflect(), other.ProtoReflect(), nil)
+}
+
+func formatResult(r *Result) string {
+	if r == nil {
+		return "<nil>"
+	}
+	return formatMessage(r.ProtoReflect())
+}
+
+func diffMessage(path string, a, b protoreflect.Message, out []string) []string {
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := fieldPath(path, string(fd.Name()))
+		hasA, hasB := a.Has(fd), b.Has(fd)
+
+		switch {
+		case fd.IsList():
+			out = diffList(name, fd, a.Get(fd).List(), b.Get(fd).List(), out)
+		case fd.Message() != nil && !fd.IsMap():
+			if hasA && hasB {
+				out = diffMessage(name, a.Get(fd).Message(), b.Get(fd).Message(), out)
+			} else if hasA != hasB {
+				out = append(out, fmt.Sprintf("%s: %s != %s", name, formatField(fd, a, hasA), formatField(fd, b, hasB)))
+			}
+		case !a.Get(fd).Equal(b.Get(fd)):
+			out = append(out, fmt.Sprintf("%s: %s != %s", name, formatField(fd, a, true), formatField(fd, b, true)))
+		}
+	}
+
+	return out
+}
+
+func diffList(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.List, out []string) []string {
+	if a.Len() != b.Len() {
+		return append(out, fmt.Sprintf("%s: %d elements != %d elements", path, a.Len(), b.Len()))
+	}
+
+	for i := 0; i < a.Len(); i++ {
+		name := fmt.Sprintf("%s[%d]", path, i)
+		if fd.Message() != nil {
+			out = diffMessage(name, a.Get(i).Message(), b.Get(i).Message(), out)
+		} else if !a.Get(i).Equal(b.Get(i)) {
+			out = append(out, fmt.Sprintf("%s: %q != %q", name, a.Get(i).String(), b.Get(i).String()))
+		}
+	}
+
+	return out
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatField(fd protoreflect.FieldDescriptor, m protoreflect.Message, has bool) string {
+	if !has {
+		return "<unset>"
+	}
+
+	v := m.Get(fd)
+	switch {
+	case fd.IsList():
+		return fmt.Sprintf("%d elements", v.List().Len())
+	case fd.IsMap():
+		return fmt.Sprintf("%d entries", v.Map().Len())
+	case fd.Message() != nil:
+		return formatMessage(v.Message())
+	case fd.Kind() == protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	case fd.Kind() == protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	default:
+		return v.String()
+	}
+}
+
+func formatMessage(m protoreflect.Message) string {
+	text := strings.TrimSpace(prototext.MarshalOptions{}.Format(m.Interface()))
+	return "{" + text + "}"
+}
@@ -0,0 +1,67 @@
+package wire
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResult_Equal(t *testing.T) {
+	a := &Result{Status: Status_OK, Fingerprint64: 7, Response: &Result_GETRes{GETRes: &GETRes{Value: "v"}}}
+	b := &Result{Status: Status_OK, Fingerprint64: 7, Response: &Result_GETRes{GETRes: &GETRes{Value: "v"}}}
+	_ = a.String() // populates internal state on a only
+
+	if !a.Equal(b) {
+		t.Errorf("Equal() got = false, want true")
+	}
+
+	b.GetGETRes().Value = "w"
+	if a.Equal(b) {
+		t.Errorf("Equal() got = true, want false")
+	}
+}
+
+func TestResult_Diff(t *testing.T) {
+	zrange := func(scores ...int64) *Result {
+		elements := make([]*ZElement, len(scores))
+		for i, s := range scores {
+			elements[i] = &ZElement{Member: "m", Score: s}
+		}
+		return &Result{Status: Status_OK, Response: &Result_ZRANGERes{ZRANGERes: &ZRANGERes{Elements: elements}}}
+	}
+
+	tests := []struct {
+		name string
+		a, b *Result
+		want []string
+	}{
+		{name: "equal", a: zrange(1, 2), b: zrange(1, 2), want: nil},
+		{name: "nested score", a: zrange(1, 2), b: zrange(1, 3), want: []string{"ZRANGERes.elements[1].score: 2 != 3"}},
+		{name: "length", a: zrange(1), b: zrange(1, 2), want: []string{"ZRANGERes.elements: 1 elements != 2 elements"}},
+		{
+			name: "status and message",
+			a:    &Result{Status: Status_OK, Message: "OK"},
+			b:    &Result{Status: Status_ERR, Message: "ERR"},
+			want: []string{"status: OK != ERR", `message: "OK" != "ERR"`},
+		},
+		{
+			name: "response type",
+			a:    &Result{Response: &Result_GETRes{GETRes: &GETRes{Value: "v"}}},
+			b:    &Result{Response: &Result_GETDELRes{GETDELRes: &GETDELRes{Value: "v"}}},
+			want: []string{"GETRes: ", "GETDELRes: <unset> != "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.a.Diff(tt.b)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Diff() got = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("Diff()[%d] got = %q, want prefix %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
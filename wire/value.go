@@ -0,0 +1,166 @@
+package wire
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Err returns nil for a Status_OK result and an error carrying Message for a
+// Status_ERR one. The error is untyped: it is not a dicedb.ServerError or
+// dicedb.ClientError, and errors.Is does not match it against the dicedb
+// sentinels. Use the client's typed helpers, such as Get or FireTyped, for a
+// classified error.
+func (r *Result) Err() error {
+	if r.GetStatus() != Status_ERR {
+		return nil
+	}
+
+	return errors.New(r.GetMessage())
+}
+
+// Text returns the string payload of a GET, GETEX, GETSET, GETDEL, HGET, ECHO,
+// PING or TYPE reply.
+func (r *Result) Text() (string, error) {
+	if err := r.Err(); err != nil {
+		return "", err
+	}
+
+	switch res := r.GetResponse().(type) {
+	case *Result_GETRes:
+		return res.GETRes.GetValue(), nil
+	case *Result_GETEXRes:
+		return res.GETEXRes.GetValue(), nil
+	case *Result_GETSETRes:
+		return res.GETSETRes.GetValue(), nil
+	case *Result_GETDELRes:
+		return res.GETDELRes.GetValue(), nil
+	case *Result_HGETRes:
+		return res.HGETRes.GetValue(), nil
+	case *Result_ECHORes:
+		return res.ECHORes.GetMessage(), nil
+	case *Result_PINGRes:
+		return res.PINGRes.GetMessage(), nil
+	case *Result_TYPERes:
+		return res.TYPERes.GetType(), nil
+	default:
+		return "", r.mismatch("a string")
+	}
+}
+
+// Int64 returns the integer payload of a counter, count, TTL or EXPIRETIME
+// reply.
+func (r *Result) Int64() (int64, error) {
+	if err := r.Err(); err != nil {
+		return 0, err
+	}
+
+	switch res := r.GetResponse().(type) {
+	case *Result_INCRRes:
+		return res.INCRRes.GetValue(), nil
+	case *Result_DECRRes:
+		return res.DECRRes.GetValue(), nil
+	case *Result_INCRBYRes:
+		return res.INCRBYRes.GetValue(), nil
+	case *Result_DECRBYRes:
+		return res.DECRBYRes.GetValue(), nil
+	case *Result_DELRes:
+		return res.DELRes.GetCount(), nil
+	case *Result_EXISTSRes:
+		return res.EXISTSRes.GetCount(), nil
+	case *Result_HSETRes:
+		return res.HSETRes.GetCount(), nil
+	case *Result_ZADDRes:
+		return res.ZADDRes.GetCount(), nil
+	case *Result_ZCOUNTRes:
+		return res.ZCOUNTRes.GetCount(), nil
+	case *Result_ZREMRes:
+		return res.ZREMRes.GetCount(), nil
+	case *Result_ZCARDRes:
+		return res.ZCARDRes.GetCount(), nil
+	case *Result_GEOADDRes:
+		return res.GEOADDRes.GetCount(), nil
+	case *Result_TTLRes:
+		return res.TTLRes.GetSeconds(), nil
+	case *Result_EXPIRETIMERes:
+		return res.EXPIRETIMERes.GetUnixSec(), nil
+	default:
+		return 0, r.mismatch("an int64")
+	}
+}
+
+// Bool returns whether an EXPIRE or EXPIREAT reply changed the expiry.
+func (r *Result) Bool() (bool, error) {
+	if err := r.Err(); err != nil {
+		return false, err
+	}
+
+	switch res := r.GetResponse().(type) {
+	case *Result_EXPIRERes:
+		return res.EXPIRERes.GetIsChanged(), nil
+	case *Result_EXPIREATRes:
+		return res.EXPIREATRes.GetIsChanged(), nil
+	default:
+		return false, r.mismatch("a bool")
+	}
+}
+
+// Strings returns the keys of a KEYS reply, the hashes of a GEOHASH reply, or
+// the members, in order, of a ZRANGE, ZPOPMAX or ZPOPMIN reply.
+func (r *Result) Strings() ([]string, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	switch res := r.GetResponse().(type) {
+	case *Result_KEYSRes:
+		return res.KEYSRes.GetKeys(), nil
+	case *Result_GEOHASHRes:
+		return res.GEOHASHRes.GetHashes(), nil
+	case *Result_ZRANGERes:
+		return Members(res.ZRANGERes.GetElements()), nil
+	case *Result_ZPOPMAXRes:
+		return Members(res.ZPOPMAXRes.GetElements()), nil
+	case *Result_ZPOPMINRes:
+		return Members(res.ZPOPMINRes.GetElements()), nil
+	default:
+		return nil, r.mismatch("a list of strings")
+	}
+}
+
+// Map returns the fields of an HGETALL reply.
+func (r *Result) Map() (map[string]string, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	res, ok := r.GetResponse().(*Result_HGETALLRes)
+	if !ok {
+		return nil, r.mismatch("a map")
+	}
+
+	m := make(map[string]string, len(res.HGETALLRes.GetElements()))
+	for _, e := range res.HGETALLRes.GetElements() {
+		m[e.GetKey()] = e.GetValue()
+	}
+
+	return m, nil
+}
+
+func (r *Result) mismatch(want string) error {
+	payload := "empty"
+	if r.GetResponse() != nil {
+		payload = fmt.Sprintf("%T", r.GetResponse())
+	}
+
+	return fmt.Errorf("cannot read %s reply as %s", payload, want)
+}
+
+// Members returns the member of each element, in order.
+func Members(elements []*ZElement) []string {
+	out := make([]string, len(elements))
+	for i, e := range elements {
+		out[i] = e.GetMember()
+	}
+
+	return out
+}
@@ -0,0 +1,68 @@
+package wire
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestResult_Err(t *testing.T) {
+	if err := (&Result{Status: Status_OK}).Err(); err != nil {
+		t.Errorf("Err() on OK got = %v, want nil", err)
+	}
+
+	err := (&Result{Status: Status_ERR, Message: "ERR boom"}).Err()
+	if err == nil || err.Error() != "ERR boom" {
+		t.Errorf("Err() on ERR got = %v, want ERR boom", err)
+	}
+}
+
+func TestResult_Values(t *testing.T) {
+	errResult := &Result{Status: Status_ERR, Message: "ERR boom"}
+	empty := &Result{Status: Status_OK}
+
+	text, err := (&Result{Response: &Result_GETRes{GETRes: &GETRes{Value: "v"}}}).Text()
+	if err != nil || text != "v" {
+		t.Errorf("Text() got = %q, %v, want v", text, err)
+	}
+
+	n, err := (&Result{Response: &Result_INCRRes{INCRRes: &INCRRes{Value: 3}}}).Int64()
+	if err != nil || n != 3 {
+		t.Errorf("Int64() got = %d, %v, want 3", n, err)
+	}
+
+	b, err := (&Result{Response: &Result_EXPIRERes{EXPIRERes: &EXPIRERes{IsChanged: true}}}).Bool()
+	if err != nil || !b {
+		t.Errorf("Bool() got = %v, %v, want true", b, err)
+	}
+
+	zrange := &Result{Response: &Result_ZRANGERes{ZRANGERes: &ZRANGERes{Elements: []*ZElement{{Member: "a"}, {Member: "b"}}}}}
+	strs, err := zrange.Strings()
+	if err != nil || !slices.Equal(strs, []string{"a", "b"}) {
+		t.Errorf("Strings() got = %v, %v, want [a b]", strs, err)
+	}
+
+	hgetall := &Result{Response: &Result_HGETALLRes{HGETALLRes: &HGETALLRes{Elements: []*HElement{{Key: "f", Value: "v"}}}}}
+	m, err := hgetall.Map()
+	if err != nil || !maps.Equal(m, map[string]string{"f": "v"}) {
+		t.Errorf("Map() got = %v, %v, want map[f:v]", m, err)
+	}
+
+	for _, r := range []*Result{errResult, empty, zrange} {
+		if _, err := r.Text(); err == nil {
+			t.Errorf("Text() on %v error = nil, want error", r)
+		}
+		if _, err := r.Int64(); err == nil {
+			t.Errorf("Int64() on %v error = nil, want error", r)
+		}
+		if _, err := r.Bool(); err == nil {
+			t.Errorf("Bool() on %v error = nil, want error", r)
+		}
+		if _, err := r.Map(); err == nil {
+			t.Errorf("Map() on %v error = nil, want error", r)
+		}
+	}
+	if _, err := errResult.Strings(); err == nil || err.Error() != "ERR boom" {
+		t.Errorf("Strings() on ERR error = %v, want ERR boom", err)
+	}
+}